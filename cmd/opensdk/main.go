@@ -22,23 +22,10 @@ import (
 	"github.com/edsonmichaque/opensdk-cli/internal/cmd"
 )
 
-const (
-	exitSuccess = 0
-	exitFailure = 1
-)
-
 func main() {
 	os.Exit(runCmd())
 }
 
 func runCmd() int {
-	if err := cmd.Run(); err != nil {
-		if cmdErr, ok := err.(cmd.CmdError); ok {
-			return cmdErr.Code
-		}
-
-		return exitFailure
-	}
-
-	return exitSuccess
+	return cmd.ExitCode(cmd.Run())
 }
@@ -0,0 +1,107 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command sign signs the checksums of a release with the Ed25519 key in
+// OPENSDK_SIGNING_KEY, whose public half is internal/update/release.pub.
+// It fails when release.pub is empty or does not match the key, since the
+// signature could not be verified by the released binaries; see
+// docs/release-signing.md.
+//
+//	go run ./hack/sign checksums.txt   # writes checksums.txt.sig
+//	go run ./hack/sign -keygen         # prints a new key pair
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	envSigningKey = "OPENSDK_SIGNING_KEY"
+	releaseKey    = "internal/update/release.pub"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "sign:", err)
+		os.Exit(1)
+	}
+}
+
+// run
+func run() error {
+	keygen := flag.Bool("keygen", false, "print a new key pair: the public key for release.pub and the private key for "+envSigningKey)
+	flag.Parse()
+
+	if *keygen {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("public: ", base64.StdEncoding.EncodeToString(pub))
+		fmt.Println("private:", base64.StdEncoding.EncodeToString(priv.Seed()))
+
+		return nil
+	}
+
+	if flag.NArg() != 1 {
+		return errors.New("usage: sign <file>")
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(os.Getenv(envSigningKey)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return fmt.Errorf("%s must be a base64 Ed25519 private key", envSigningKey)
+	}
+
+	key := ed25519.NewKeyFromSeed(seed)
+	if err := checkReleaseKey(key.Public().(ed25519.PublicKey)); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	sig := ed25519.Sign(key, data)
+
+	return os.WriteFile(flag.Arg(0)+".sig", []byte(base64.StdEncoding.EncodeToString(sig)+"\n"), 0o644)
+}
+
+// checkReleaseKey fails unless pub is the key committed in release.pub
+func checkReleaseKey(pub ed25519.PublicKey) error {
+	data, err := os.ReadFile(releaseKey)
+	if err != nil {
+		return err
+	}
+
+	committed := strings.TrimSpace(string(data))
+	if committed == "" {
+		return fmt.Errorf("%s is empty: provision the release key first, see docs/release-signing.md", releaseKey)
+	}
+
+	if committed != base64.StdEncoding.EncodeToString(pub) {
+		return fmt.Errorf("%s does not match the public half of %s", releaseKey, envSigningKey)
+	}
+
+	return nil
+}
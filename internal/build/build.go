@@ -18,5 +18,6 @@ package build
 
 var (
 	Version = "dev"
+	Commit  = ""
 	Date    = ""
 )
@@ -0,0 +1,152 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+)
+
+const apiErrorBodyLimit = 64 << 10
+
+// requestIDHeaders are the response headers the request ID is read from,
+// in order of preference
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"Request-Id",
+	"X-Correlation-Id",
+}
+
+// apiError is a failed API response
+type apiError struct {
+	StatusCode int
+	RequestID  string
+	Message    string
+	Path       string
+}
+
+// Error
+func (e apiError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	if e.RequestID == "" {
+		return msg
+	}
+
+	return fmt.Sprintf("%s (request ID: %s)", msg, e.RequestID)
+}
+
+// Hint suggests how to fix the most common API failures
+func (e apiError) Hint() string {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return i18n.T(
+			"check your access token: run `%s init` or `%s config set %s`",
+			cmdName, cmdName, optAccessToken,
+		)
+	case http.StatusForbidden:
+		return i18n.T("the access token cannot use this account; check the %s setting of the profile", optAccount)
+	case http.StatusNotFound:
+		for _, segment := range strings.Split(e.Path, "/") {
+			if len(segment) > 1 && strings.HasSuffix(segment, ".") {
+				return i18n.T("did you mean %q without the trailing dot?", strings.TrimSuffix(segment, "."))
+			}
+		}
+
+		return i18n.T("check the name or ID, and that it belongs to the account")
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return i18n.T("check the values sent; run with --%s debug to see the API response", optLogLevel)
+	case http.StatusTooManyRequests:
+		return i18n.T("the rate limit was reached; wait a moment and try again")
+	default:
+		if e.StatusCode >= http.StatusInternalServerError {
+			return i18n.T("the API failed to handle the request; try again later and quote the request ID when reporting it")
+		}
+
+		return ""
+	}
+}
+
+// newAPIError turns a failed response into a CmdError carrying the request
+// ID and the exit code matching the status
+func newAPIError(opts *Opts, resp *http.Response) CmdError {
+	err := apiError{StatusCode: resp.StatusCode}
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		err.Path = resp.Request.URL.Path
+	}
+
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			err.RequestID = id
+			break
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, apiErrorBodyLimit))
+
+	opts.log.Debug("API request failed", "status", resp.StatusCode, "path", err.Path, "body", string(body))
+
+	var payload struct {
+		Message string `json:"message"`
+	}
+
+	if json.Unmarshal(body, &payload) == nil {
+		err.Message = payload.Message
+	} else {
+		err.Message = strings.TrimSpace(string(body))
+	}
+
+	return wrapError(apiExitCode(resp.StatusCode), err)
+}
+
+// apiExitCode
+func apiExitCode(status int) int {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return exitAuth
+	case status == http.StatusNotFound:
+		return exitNotFound
+	case status == http.StatusTooManyRequests:
+		return exitRateLimited
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return exitValidation
+	case status >= http.StatusInternalServerError:
+		return exitServer
+	default:
+		return exitFailure
+	}
+}
+
+// requestID returns the ID of the failed API request behind err, if any
+func requestID(err error) string {
+	var apiErr apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.RequestID
+	}
+
+	return ""
+}
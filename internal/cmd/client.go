@@ -0,0 +1,181 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/build"
+)
+
+const (
+	clientIdleConns      = 100
+	clientIdleTimeout    = 90 * time.Second
+	clientMaxRetries     = 3
+	clientMaxRetryWait   = time.Minute
+	clientDialTimeout    = 30 * time.Second
+	clientHandshakeLimit = 10 * time.Second
+)
+
+// httpClient returns the client for the API calls of the run, shared by
+// every request so connections are kept alive and reused. Its transport
+// traces and counts requests for --stats and the rate limit, retries
+// rate-limited ones, and records or replays them when OPENSDK_VCR is set.
+// Timeouts are set per request, with requestContext.
+func (s *runState) httpClient() *http.Client {
+	s.clientOnce.Do(s.initClients)
+
+	return s.client
+}
+
+// externalClient returns the client for the requests that are not API
+// calls, such as release lookups, telemetry and notifications. It shares
+// the connections of httpClient but is left out of --stats and the rate
+// limit, which are about the API.
+func (s *runState) externalClient() *http.Client {
+	s.clientOnce.Do(s.initClients)
+
+	return s.external
+}
+
+// initClients builds the clients returned by httpClient and externalClient
+func (s *runState) initClients() {
+	base := newVCRTransport(s.log, &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   clientDialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          clientIdleConns,
+		MaxIdleConnsPerHost:   clientIdleConns,
+		IdleConnTimeout:       clientIdleTimeout,
+		TLSHandshakeTimeout:   clientHandshakeLimit,
+		ExpectContinueTimeout: time.Second,
+	})
+
+	s.client = &http.Client{
+		Transport: &tracingTransport{
+			base: &statsTransport{
+				base:  &retryTransport{base: base, state: s},
+				state: s,
+			},
+			state: s,
+		},
+	}
+
+	s.external = &http.Client{
+		Transport: &tracingTransport{
+			base:  &retryTransport{base: base},
+			state: s,
+		},
+	}
+}
+
+// requestContext bounds a request made with httpClient or externalClient to
+// timeout
+func requestContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// retryTransport sets the User-Agent and retries requests answered with
+// 429 Too Many Requests once the Retry-After delay has passed
+type retryTransport struct {
+	base http.RoundTripper
+
+	// state, when set, counts the retries and waits for --stats
+	state *runState
+}
+
+// RoundTrip
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", cmdName+"/"+build.Version)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt == clientMaxRetries {
+			return resp, err
+		}
+
+		wait, ok := retryAfter(resp)
+		if !ok || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+
+		if t.state != nil {
+			t.state.statsRateLimitWait(wait)
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		if t.state != nil {
+			t.state.statsRetry()
+		}
+	}
+}
+
+// retryAfter returns how long the response asks to wait, if it is short
+// enough to wait for
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return time.Second, true
+	}
+
+	var wait time.Duration
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = time.Until(at)
+	} else {
+		return 0, false
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait, wait <= clientMaxRetryWait
+}
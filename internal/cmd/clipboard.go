@@ -0,0 +1,56 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCmds lists the programs tried, in order, to write the clipboard
+var clipboardCmds = map[string][][]string{
+	"darwin":  {{"pbcopy"}},
+	"windows": {{"clip.exe"}},
+	"linux": {
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	},
+}
+
+// copyToClipboard writes value to the system clipboard
+func copyToClipboard(value string) error {
+	for _, args := range clipboardCmds[runtime.GOOS] {
+		if args[0] == "wl-copy" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			continue
+		}
+
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+
+		c := exec.Command(args[0], args[1:]...)
+		c.Stdin = strings.NewReader(value)
+
+		return c.Run()
+	}
+
+	return errors.New("no clipboard utility found")
+}
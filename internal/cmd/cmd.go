@@ -19,7 +19,29 @@ package cmd
 import (
 	"errors"
 	"io"
+	"net/http"
 	"os"
+	"sync"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exit codes returned by the CLI. They are part of the public interface,
+// see docs/exit-codes.md.
+const (
+	exitSuccess     = 0
+	exitFailure     = 1
+	exitUsage       = 2
+	exitAuth        = 3
+	exitNotFound    = 4
+	exitRateLimited = 5
+	exitServer      = 6
+	exitValidation  = 7
+	exitPartial     = 8
+	exitInterrupted = 130
 )
 
 // CmdError
@@ -33,6 +55,69 @@ func (e CmdError) Error() string {
 	return e.Err.Error()
 }
 
+// Unwrap
+func (e CmdError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode maps an error returned by Run to the process exit code. Commands
+// report their failures as CmdError; anything else comes from cobra itself
+// (unknown commands, bad flags or arguments) and is a usage error.
+func ExitCode(err error) int {
+	if err == nil {
+		return exitSuccess
+	}
+
+	var cmdErr CmdError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code
+	}
+
+	return exitUsage
+}
+
+// errorCodes names exit codes in machine-readable error output
+var errorCodes = map[int]string{
+	exitFailure:     "failure",
+	exitUsage:       "usage_error",
+	exitAuth:        "auth_failure",
+	exitNotFound:    "not_found",
+	exitRateLimited: "rate_limited",
+	exitServer:      "server_error",
+	exitValidation:  "validation_error",
+	exitPartial:     "partial_failure",
+	exitInterrupted: "interrupted",
+}
+
+// errorOutput is the JSON representation of a failed command
+type errorOutput struct {
+	Code      string   `json:"code"`
+	ExitCode  int      `json:"exit_code"`
+	Message   string   `json:"message"`
+	RequestID string   `json:"request_id"`
+	Hint      string   `json:"hint,omitempty"`
+	Details   []string `json:"details"`
+}
+
+// newErrorOutput
+func newErrorOutput(err error) errorOutput {
+	code := ExitCode(err)
+
+	name, ok := errorCodes[code]
+	if !ok {
+		name = errorCodes[exitFailure]
+	}
+
+	return errorOutput{
+		Code:      name,
+		ExitCode:  code,
+		Message:   err.Error(),
+		RequestID: requestID(err),
+		Hint:      errorHint(err),
+		Details:   []string{},
+	}
+}
+
 // newError
 func newError(code int, err string) CmdError {
 	return wrapError(code, errors.New(err))
@@ -54,22 +139,171 @@ func InitOpts() (*Opts, error) {
 	}
 
 	return &Opts{
-		Stdin:   os.Stdin,
-		Stderr:  os.Stderr,
-		Stdout:  os.Stdout,
-		WorkDir: wd,
+		Stdin:    os.Stdin,
+		Stderr:   os.Stderr,
+		Stdout:   os.Stdout,
+		WorkDir:  wd,
+		Viper:    newViper(),
+		runState: newRunState(),
 	}, nil
 }
 
 // Opts
 type Opts struct {
-	Stdout  io.Writer
-	Stdin   io.Reader
-	Stderr  io.Writer
-	WorkDir string
+	Stdout     io.Writer
+	Stdin      io.Reader
+	Stderr     io.Writer
+	WorkDir    string
+	ConfigFile string
+
+	// Viper holds the configuration of a single run: the configuration
+	// file, OPENSDK_* environment variables and the flags of the command
+	// being executed. Each command tree gets its own, so flags never leak
+	// from one run into the next.
+	Viper *viper.Viper
+
+	logFormat string
+	logLevel  string
+	profile   string
+
+	*runState
+}
+
+// runState is what a command tree accumulates while it runs. Each tree gets
+// its own, so that trees built side by side, or one run from another, never
+// see each other's logs, statistics or connections.
+type runState struct {
+	// log receives the log records of the run
+	log *logging.Logger
+
+	// lazyCmds maps the placeholders added by withLazyCmd to the functions
+	// building the commands they stand for
+	lazyCmds map[*cobra.Command]func() *Cmd
+
+	statsMu sync.Mutex
+	stats   cmdStats
+
+	clientOnce sync.Once
+	client     *http.Client
+	external   *http.Client
+
+	// cmdSpan is the span of the command being executed, the parent of the
+	// spans of requests that carry no span in their context
+	spanMu  sync.Mutex
+	cmdSpan trace.Span
+
+	// initialized is set once the configuration is loaded and logging is
+	// set up, so that the commands run by a shell share them
+	initialized bool
+}
+
+// newRunState
+func newRunState() *runState {
+	return &runState{
+		log:      logging.New(os.Stderr, logging.LevelWarn, logging.FormatText),
+		lazyCmds: map[*cobra.Command]func() *Cmd{},
+	}
+}
+
+// fork returns options for another run with the same streams and a fresh
+// configuration, logger, statistics and HTTP client
+func (c *Opts) fork() *Opts {
+	return &Opts{
+		Stdin:      c.Stdin,
+		Stdout:     c.Stdout,
+		Stderr:     c.Stderr,
+		WorkDir:    c.WorkDir,
+		ConfigFile: c.ConfigFile,
+		Viper:      newViper(),
+		runState:   newRunState(),
+	}
+}
+
+// Option configures the command tree built by NewRoot
+type Option func(*Opts)
+
+// WithStdin sets the standard input of the commands
+func WithStdin(r io.Reader) Option {
+	return func(o *Opts) {
+		o.Stdin = r
+	}
+}
+
+// WithStdout sets the standard output of the commands
+func WithStdout(w io.Writer) Option {
+	return func(o *Opts) {
+		o.Stdout = w
+	}
+}
+
+// WithStderr sets the standard error of the commands
+func WithStderr(w io.Writer) Option {
+	return func(o *Opts) {
+		o.Stderr = w
+	}
+}
+
+// WithWorkDir sets the directory hooks and extensions run in
+func WithWorkDir(dir string) Option {
+	return func(o *Opts) {
+		o.WorkDir = dir
+	}
+}
+
+// WithViper sets the configuration the commands read, instead of one
+// loaded from the configuration file and environment
+func WithViper(v *viper.Viper) Option {
+	return func(o *Opts) {
+		o.Viper = v
+	}
+}
+
+// WithConfigFile reads the configuration from file instead of the profile
+// files, as --config-file does
+func WithConfigFile(file string) Option {
+	return func(o *Opts) {
+		o.ConfigFile = file
+	}
+}
+
+// NewRoot builds the whole command tree, reading from and writing to the
+// standard streams unless opts say otherwise. Programs embedding the CLI
+// run it with SetArgs and ExecuteC, and map the error with ExitCode.
+// Aliases and extensions are only resolved by Run.
+//
+// Each tree has its own configuration, logger, statistics and HTTP client,
+// so several can run side by side. The language of the messages and the
+// colors of the prompts are set for the whole process by the last tree to
+// start, and the trace exporter by the first one.
+func NewRoot(opts ...Option) (*cobra.Command, error) {
+	o, err := InitOpts()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	root := cmdRoot(o)
+	loadAllCmds(o, root.Command)
+
+	return root.Command, nil
 }
 
 // Validate
 func (c Opts) Validate() error {
+	if c.Stdin == nil || c.Stdout == nil || c.Stderr == nil {
+		return errors.New("stdin, stdout and stderr are required")
+	}
+
+	if c.Viper == nil {
+		return errors.New("configuration is required")
+	}
+
 	return nil
 }
@@ -0,0 +1,235 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+	"github.com/kballard/go-shellquote"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	cfgAliases = "aliases"
+)
+
+var aliasPlaceholder = regexp.MustCompile(`\$(\d+)`)
+
+// cmdAlias
+func cmdAlias(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage command aliases",
+	}
+
+	return initCmd(
+		cmd,
+		withOpts(opts),
+		withCmd(
+			cmdAliasSet(opts),
+			cmdAliasList(opts),
+			cmdAliasDelete(opts),
+		),
+	)
+}
+
+// cmdAliasSet
+func cmdAliasSet(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "set <name> <expansion>",
+		Short: "Create a command alias",
+		Args:  cobra.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, expansion := args[0], args[1]
+
+			root := cmd.Root()
+			if found, _, err := root.Find([]string{name}); err == nil && found != root {
+				return newError(exitUsage, fmt.Sprintf(`"%s" is already an opensdk command`, name))
+			}
+
+			expanded, err := shellquote.Split(expansion)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			if len(expanded) == 0 {
+				return newError(exitUsage, "alias expansion is empty")
+			}
+
+			if found, _, err := root.Find(expanded); err != nil || found == root {
+				return newError(exitUsage, fmt.Sprintf(`"%s" is not an opensdk command`, expanded[0]))
+			}
+
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action:  "set",
+				Target:  opts.Viper.ConfigFileUsed(),
+				Changes: map[string]interface{}{cfgAliases + "." + name: expansion},
+			}); ok {
+				return err
+			}
+
+			var before interface{}
+			if old, ok := opts.Viper.GetStringMapString(cfgAliases)[name]; ok {
+				before = old
+			}
+
+			if err := updateCfgFile(opts, func(v *viper.Viper) {
+				aliases := v.GetStringMapString(cfgAliases)
+				aliases[name] = expansion
+
+				v.Set(cfgAliases, aliases)
+			}); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			recordAudit(cmd, opts, "alias/"+name, before, expansion)
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// cmdAliasList
+func cmdAliasList(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List command aliases",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			aliases := opts.Viper.GetStringMapString(cfgAliases)
+
+			names := make([]string, 0, len(aliases))
+			for name := range aliases {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				cmd.Printf("%s: %s\n", name, aliases[name])
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// cmdAliasDelete
+func cmdAliasDelete(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a command alias",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			aliases := opts.Viper.GetStringMapString(cfgAliases)
+			if _, ok := aliases[args[0]]; !ok {
+				return newError(exitNotFound, fmt.Sprintf(`no such alias "%s"`, args[0]))
+			}
+
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action:  "delete",
+				Target:  opts.Viper.ConfigFileUsed(),
+				Changes: map[string]interface{}{cfgAliases + "." + args[0]: nil},
+			}); ok {
+				return err
+			}
+
+			if err := confirmAction(opts, i18n.T(`Delete alias "%s"?`, args[0])); err != nil {
+				return err
+			}
+
+			if err := updateCfgFile(opts, func(v *viper.Viper) {
+				aliases := v.GetStringMapString(cfgAliases)
+				delete(aliases, args[0])
+
+				v.Set(cfgAliases, aliases)
+			}); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			recordAudit(cmd, opts, "alias/"+args[0], aliases[args[0]], nil)
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// expandAlias replaces a leading alias in args with its expansion. Arguments
+// referenced by $N placeholders are substituted, the rest are appended.
+func expandAlias(root *Cmd, opts *Opts, args []string) ([]string, error) {
+	flags, rest := splitGlobalFlags(root, args)
+	if len(rest) == 0 {
+		return args, nil
+	}
+
+	if found, _, err := root.Find(rest); err == nil && found != root.Command {
+		return args, nil
+	}
+
+	initDispatchCfg(root, opts)
+
+	expansion, ok := opts.Viper.GetStringMapString(cfgAliases)[rest[0]]
+	if !ok {
+		return args, nil
+	}
+
+	expanded, err := shellquote.Split(expansion)
+	if err != nil {
+		return nil, err
+	}
+
+	extra := rest[1:]
+	used := make(map[int]struct{})
+
+	for i, arg := range expanded {
+		expanded[i] = aliasPlaceholder.ReplaceAllStringFunc(arg, func(s string) string {
+			n, _ := strconv.Atoi(s[1:])
+			if n < 1 || n > len(extra) {
+				return s
+			}
+
+			used[n-1] = struct{}{}
+
+			return extra[n-1]
+		})
+	}
+
+	for i, arg := range extra {
+		if _, ok := used[i]; !ok {
+			expanded = append(expanded, arg)
+		}
+	}
+
+	return append(append([]string{}, flags...), expanded...), nil
+}
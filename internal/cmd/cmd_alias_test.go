@@ -0,0 +1,57 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandAlias(t *testing.T) {
+	cfg := "aliases:\n  acct: config get account\n  get: config get $1 --output json\n"
+
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"command", "config get account", "config get account"},
+		{"alias", "acct", "config get account"},
+		{"extra arguments", "acct --output yaml", "config get account --output yaml"},
+		{"placeholder", "get account", "config get account --output json"},
+		{"missing placeholder", "get", "config get $1 --output json"},
+		{"global flags", "--profile prod acct", "--profile prod config get account"},
+		{"unknown", "nosuch", "nosuch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, opts := newTestTree(t, writeTestCfg(t, cfg), io.Discard, io.Discard)
+
+			got, err := expandAlias(root, opts, strings.Fields(tt.args))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := strings.Fields(tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
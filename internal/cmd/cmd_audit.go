@@ -0,0 +1,251 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/cmd/formatter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	auditLogFile = "audit.jsonl"
+)
+
+// cmdAudit
+func cmdAudit(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the log of changes made from this machine",
+	}
+
+	return initCmd(
+		cmd,
+		withOpts(opts),
+		withCmd(cmdAuditList(opts)),
+	)
+}
+
+// cmdAuditList
+func cmdAuditList(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recorded changes",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdPreRun(
+				func() error {
+					return opts.Viper.BindPFlags(cmd.Flags())
+				},
+				func() error {
+					return validateFlags(cmd, opts, flagEnum(optOutput, outputJSON, outputYAML, outputTable))
+				},
+			)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var since time.Time
+
+			if value := opts.Viper.GetString(optSince); value != "" {
+				var err error
+
+				since, err = parseSince(value, time.Now())
+				if err != nil {
+					return wrapError(exitUsage, err)
+				}
+			}
+
+			entries, err := readAudit(since)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			entries, err = filterItems(opts, entries)
+			if err != nil {
+				return err
+			}
+
+			if err := failOnEmpty(opts, len(entries)); err != nil {
+				return err
+			}
+
+			githubSummary(cmd, opts, entries)
+
+			output, err := formatter.Format(
+				entries, &formatter.Opts{
+					Output: formatter.Output(opts.Viper.GetString(optOutput)),
+					Query:  opts.Viper.GetString(optQuery),
+				},
+			)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			if err := cmdPrint(cmd, output); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagOutput(outputTable),
+		withFlagQuery(),
+		withFlagFilter(),
+		withFlagFailOnEmpty(),
+		withFlagSince(),
+		withOpts(opts),
+	)
+}
+
+// parseSince parses a look-back duration, which may use a d suffix for
+// days, or an RFC 3339 time or date
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --%s value %q", optSince, value)
+}
+
+// auditPath
+func auditPath() (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, auditLogFile), nil
+}
+
+// readAudit returns the changes recorded after since
+func readAudit(since time.Time) (formatter.AuditList, error) {
+	path, err := auditPath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return formatter.AuditList{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := formatter.AuditList{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry formatter.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+
+		if entry.Time.Before(since) {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// recordAudit appends a successful change to resource, e.g.
+// "config/account", to the audit log. Values of sensitive resources are
+// redacted. Failing to write the audit log is logged, not returned: the
+// change has already been made.
+func recordAudit(cmd *cobra.Command, opts *Opts, resource string, before, after interface{}) {
+	if isSensitive(resource) {
+		if before != nil {
+			before = redacted
+		}
+
+		if after != nil {
+			after = redacted
+		}
+	}
+
+	entry := formatter.AuditEntry{
+		Time:     time.Now().UTC(),
+		Command:  hookKey(cmd),
+		Resource: resource,
+		Before:   before,
+		After:    after,
+	}
+
+	if u, err := user.Current(); err == nil {
+		entry.User = u.Username
+	}
+
+	if host, err := os.Hostname(); err == nil {
+		entry.Host = host
+	}
+
+	if err := appendAudit(entry); err != nil {
+		opts.log.Warn("could not write audit log", "error", err)
+	}
+}
+
+// appendAudit
+func appendAudit(entry formatter.AuditEntry) error {
+	path, err := auditPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+
+	return err
+}
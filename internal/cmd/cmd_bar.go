@@ -17,15 +17,9 @@
 package cmd
 
 import (
-	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/edsonmichaque/opensdk-cli/internal/config"
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-)
-
-const (
-	exitSuccess = iota
-	exitFailure
 )
 
 // cmdBar
@@ -33,25 +27,19 @@ func cmdBar(opts *Opts) *Cmd {
 	cmd := &cobra.Command{
 		Use:   "bar",
 		Short: "List accounts",
-		Example: heredoc.Doc(`
-			opensdk bar
-			opensdk bar --output=json
-			opensdk bar --output=yaml
-			opensdk bar --output=json --query="[].id"
-		`),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return viper.BindPFlags(cmd.Flags())
+			return opts.Viper.BindPFlags(cmd.Flags())
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Init()
+			cfg, err := config.Init(opts.Viper)
 			if err != nil {
-				return wrapError(exitFailure, err)
+				return wrapError(exitFailure, cfgHint(err))
 			}
 
 			promptResp, err := execPrompt(
-				execConfirmPrompt("Do you want to do it?", false),
-				execConfirmPrompt("Do you want to do it again?", false),
-				execConfirmPrompt("Do you want to do it again again?", false),
+				execConfirmPrompt(i18n.T("Do you want to do it?"), false),
+				execConfirmPrompt(i18n.T("Do you want to do it again?"), false),
+				execConfirmPrompt(i18n.T("Do you want to do it again again?"), false),
 				execFileFmtPrompt(cfgFmtYAML),
 				execBaseURLPrompt("https://example.com"),
 			)
@@ -0,0 +1,105 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	shellBash       = "bash"
+	shellFish       = "fish"
+	shellPowershell = "powershell"
+	shellZsh        = "zsh"
+)
+
+// cmdCompletion
+func cmdCompletion(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "completion {bash|zsh|fish|powershell}",
+		Short: "Generate shell completion scripts",
+		Args:  cobra.ExactValidArgs(1),
+		ValidArgs: []string{
+			shellBash,
+			shellZsh,
+			shellFish,
+			shellPowershell,
+		},
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			out := cmd.OutOrStdout()
+
+			var err error
+
+			switch args[0] {
+			case shellBash:
+				err = root.GenBashCompletionV2(out, true)
+			case shellZsh:
+				err = root.GenZshCompletion(out)
+			case shellFish:
+				err = root.GenFishCompletion(out, true)
+			case shellPowershell:
+				err = root.GenPowerShellCompletionWithDesc(out)
+			}
+
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// completeValues
+func completeValues(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeProfiles lists the profiles stored in the configuration directory
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	files, err := profileFiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	profiles := make([]string, 0, len(files))
+	for name := range files {
+		profiles = append(profiles, name)
+	}
+
+	sort.Strings(profiles)
+
+	return profiles, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCfgFiles
+func completeCfgFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{
+		cfgFmtJSON,
+		cfgFmtYAML,
+		cfgFmtYML,
+		cfgFmtTOML,
+	}, cobra.ShellCompDirectiveFilterFileExt
+}
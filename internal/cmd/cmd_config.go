@@ -19,13 +19,18 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/edsonmichaque/opensdk-cli/internal/cmd/formatter"
 	"github.com/edsonmichaque/opensdk-cli/internal/config"
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -38,11 +43,27 @@ const (
 )
 
 var (
+	cfgFormats = map[string]struct{}{
+		cfgFmtJSON: {},
+		cfgFmtYAML: {},
+		cfgFmtTOML: {},
+		cfgFmtYML:  {},
+	}
+
 	configProps = map[string]struct{}{
-		optAccount:     {},
-		optBaseURL:     {},
-		optAccessToken: {},
-		optSandbox:     {},
+		optAccount:           {},
+		optBaseURL:           {},
+		optAccessToken:       {},
+		optSandbox:           {},
+		cfgCrashReportDSN:    {},
+		cfgLocale:            {},
+		cfgLogFile:           {},
+		cfgPushgateway:       {},
+		cfgSelfUpgrade:       {},
+		cfgStatusPage:        {},
+		cfgTelemetry:         {},
+		cfgTelemetryEndpoint: {},
+		cfgUpdateNotice:      {},
 	}
 
 	cfgValidateFuncs = map[string]func(string) (interface{}, error){
@@ -58,6 +79,47 @@ var (
 		optAccessToken: func(value string) (interface{}, error) {
 			return value, nil
 		},
+		cfgCrashReportDSN: func(value string) (interface{}, error) {
+			if _, err := url.ParseRequestURI(value); err != nil {
+				return nil, err
+			}
+
+			return value, nil
+		},
+		cfgLocale: validateLocale,
+		cfgLogFile: func(value string) (interface{}, error) {
+			return value, nil
+		},
+		cfgPushgateway: func(value string) (interface{}, error) {
+			if _, err := url.ParseRequestURI(value); err != nil {
+				return nil, err
+			}
+
+			return value, nil
+		},
+		cfgSelfUpgrade: func(value string) (interface{}, error) {
+			return strconv.ParseBool(value)
+		},
+		cfgStatusPage: func(value string) (interface{}, error) {
+			if _, err := url.ParseRequestURI(value); err != nil {
+				return nil, err
+			}
+
+			return value, nil
+		},
+		cfgTelemetry: func(value string) (interface{}, error) {
+			return strconv.ParseBool(value)
+		},
+		cfgTelemetryEndpoint: func(value string) (interface{}, error) {
+			if _, err := url.ParseRequestURI(value); err != nil {
+				return nil, err
+			}
+
+			return value, nil
+		},
+		cfgUpdateNotice: func(value string) (interface{}, error) {
+			return strconv.ParseBool(value)
+		},
 	}
 )
 
@@ -86,48 +148,120 @@ func cmdCfgInit(opts *Opts) *Cmd {
 		Short: "Initialize configuration",
 		Args:  cobra.ExactArgs(0),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return viper.BindPFlags(cmd.Flags())
+			return opts.Viper.BindPFlags(cmd.Flags())
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.LoadWithValidation(false)
+			cfg, ext, err := cfgInitValues(opts)
 			if err != nil {
-				return wrapError(exitFailure, err)
+				return err
 			}
 
-			cfg, ext, err := execConfigPrompt(cfg)
-			if err != nil {
-				return wrapError(exitFailure, err)
-			}
-
-			cfgDir, err := os.UserConfigDir()
+			cfgDir, err := profilesDir()
 			if err != nil {
 				return wrapError(exitFailure, err)
 			}
 
 			target := filepath.Join(
 				cfgDir,
-				cmdName,
 				fmt.Sprintf(
 					"%s.%s",
-					viper.GetString(optProfile),
+					opts.Viper.GetString(optProfile),
 					strings.ToLower(ext),
 				),
 			)
 
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action: "write",
+				Target: target,
+				Changes: map[string]interface{}{
+					optAccount:     cfg.Account,
+					optAccessToken: redacted,
+					optBaseURL:     cfg.BaseURL,
+				},
+			}); ok {
+				return err
+			}
+
+			if _, err := os.Stat(target); err == nil {
+				if err := confirmAction(opts, i18n.T("Overwrite %s?", target)); err != nil {
+					return err
+				}
+			}
+
+			if err := os.MkdirAll(cfgDir, 0o700); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
 			if err := writeCfg(cfg, target); err != nil {
 				return wrapError(exitFailure, err)
 			}
 
+			recordAudit(cmd, opts, "profile/"+opts.Viper.GetString(optProfile), nil, target)
+
 			return nil
 		},
 	}
 
 	return initCmd(
 		cmd,
+		withFlagFromFile(),
 		withOpts(opts),
 	)
 }
 
+// cfgInitValues reads the configuration from --from-file or piped stdin,
+// prompting for it otherwise
+func cfgInitValues(opts *Opts) (*config.Config, string, error) {
+	var cfg config.Config
+
+	ext, err := readPayload(opts, &cfg)
+	if err != nil {
+		return nil, "", wrapError(exitValidation, err)
+	}
+
+	if ext != "" {
+		if err := cfg.Validate(); err != nil {
+			return nil, "", wrapError(exitValidation, err)
+		}
+
+		return &cfg, ext, nil
+	}
+
+	current, err := config.LoadWithValidation(opts.Viper, false)
+	if err != nil {
+		return nil, "", wrapError(exitFailure, err)
+	}
+
+	prompted, ext, err := execConfigPrompt(opts, current)
+	if err != nil {
+		return nil, "", wrapError(exitFailure, err)
+	}
+
+	return prompted, ext, nil
+}
+
+// profilesDir returns the directory where profile configuration files live
+func profilesDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, cmdName), nil
+}
+
+// systemCfgDir returns the directory of the system-wide configuration:
+// /etc/opensdk, or %ProgramData%\opensdk on Windows
+func systemCfgDir() string {
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv(envProgramData); dir != "" {
+			return filepath.Join(dir, cmdName)
+		}
+	}
+
+	return pathConfigFile
+}
+
 // writeCfg
 func writeCfg(cfg *config.Config, dst string) error {
 	v := viper.New()
@@ -143,6 +277,10 @@ func writeCfg(cfg *config.Config, dst string) error {
 		v.Set(optSandbox, cfg.Sandbox)
 	}
 
+	if cfg.Output != "" {
+		v.Set(optOutput, cfg.Output)
+	}
+
 	if err := v.WriteConfigAs(dst); err != nil {
 		return wrapError(exitFailure, err)
 	}
@@ -150,20 +288,81 @@ func writeCfg(cfg *config.Config, dst string) error {
 	return nil
 }
 
+// cfgSetArgs returns the key and value for config set, prompting for the
+// ones missing from args
+func cfgSetArgs(opts *Opts, args []string) (string, string, error) {
+	var key, value string
+
+	if len(args) > 0 {
+		key = args[0]
+	}
+
+	if len(args) > 1 {
+		value = args[1]
+	}
+
+	if key == "" {
+		keys := make([]string, 0, len(configProps))
+		for prop := range configProps {
+			keys = append(keys, prop)
+		}
+
+		sort.Strings(keys)
+
+		res, err := execPrompt(execCfgKeyPrompt(keys))
+		if err != nil {
+			return "", "", err
+		}
+
+		key = res.GetString(promptKey)
+	}
+
+	if len(args) < 2 {
+		res, err := execPrompt(
+			execCfgValuePrompt(key, opts.Viper.GetString(key), cfgValidateFuncs[key]),
+		)
+		if err != nil {
+			return "", "", err
+		}
+
+		value = res.GetString(promptValue)
+	}
+
+	return key, value, nil
+}
+
+// updateCfgFile applies fn to the contents of the configuration file in
+// use and writes it back, leaving flag and environment values out of it.
+func updateCfgFile(opts *Opts, fn func(v *viper.Viper)) error {
+	file := opts.Viper.ConfigFileUsed()
+	if file == "" {
+		return errors.New("no configuration file found")
+	}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+
+	if err := v.ReadInConfig(); err != nil {
+		return err
+	}
+
+	fn(v)
+
+	return v.WriteConfig()
+}
+
 // cmdCfgGet
 func cmdCfgGet(opts *Opts) *Cmd {
 	cmd := &cobra.Command{
 		Use:   "get",
 		Short: "Manage configurations",
 		Args: func(cmd *cobra.Command, args []string) error {
-			prop := args[0]
-
 			return cmdPreRun(
 				func() error {
 					return cobra.ExactArgs(1)(cmd, args)
 				},
 				func() error {
-					if _, ok := configProps[prop]; !ok {
+					if _, ok := configProps[args[0]]; !ok {
 						return errors.New("not found")
 					}
 
@@ -172,29 +371,38 @@ func cmdCfgGet(opts *Opts) *Cmd {
 			)
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return viper.BindPFlags(cmd.Flags())
+			return opts.Viper.BindPFlags(cmd.Flags())
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg := &config.Config{
-				Account:     "abc123456",
-				AccessToken: "def456789",
-				BaseURL:     "https://example.com",
+			value := opts.Viper.GetString(args[0])
+
+			typ := reflect.TypeOf(opts.Viper.Get(args[0]))
+			if typ == nil {
+				typ = reflect.TypeOf(value)
 			}
 
 			resp, err := formatter.Format(
-				formatter.ToConfigList(cfg),
+				formatter.ConfigList{{Name: args[0], Type: typ, Value: value}},
 				&formatter.Opts{
 					Output: formatter.Output(
-						viper.GetString(optOutput),
+						opts.Viper.GetString(optOutput),
 					),
 				},
 			)
 			if err != nil {
-				return wrapError(1, err)
+				return wrapError(exitFailure, err)
 			}
 
 			if err := cmdPrint(cmd, resp); err != nil {
-				return wrapError(1, err)
+				return wrapError(exitFailure, err)
+			}
+
+			if opts.Viper.GetBool(optCopy) {
+				if err := copyToClipboard(value); err != nil {
+					return wrapError(exitFailure, err)
+				}
+
+				cmd.PrintErrf("Copied %s to the clipboard\n", args[0])
 			}
 
 			return nil
@@ -204,6 +412,7 @@ func cmdCfgGet(opts *Opts) *Cmd {
 	return initCmd(
 		cmd,
 		withFlagOutput(outputTable),
+		withFlagCopy(),
 		withOpts(opts),
 	)
 }
@@ -216,9 +425,17 @@ func cmdCfgSet(opts *Opts) *Cmd {
 		Args: func(cmd *cobra.Command, args []string) error {
 			return cmdPreRun(
 				func() error {
-					return cobra.ExactArgs(2)(cmd, args)
+					if noInteractive, _ := cmd.Flags().GetBool(optNoInteractive); noInteractive {
+						return cobra.ExactArgs(2)(cmd, args)
+					}
+
+					return cobra.MaximumNArgs(2)(cmd, args)
 				},
 				func() error {
+					if len(args) == 0 {
+						return nil
+					}
+
 					if _, ok := configProps[args[0]]; !ok {
 						return errors.New("not found")
 					}
@@ -227,23 +444,47 @@ func cmdCfgSet(opts *Opts) *Cmd {
 				},
 			)
 		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			validateCfg := cfgValidateFuncs[args[0]]
+			key, rawValue, err := cfgSetArgs(opts, args)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			validateCfg := cfgValidateFuncs[key]
 			if validateCfg == nil {
 				return newError(exitFailure, "no validator found")
 			}
 
-			value, err := validateCfg(args[1])
+			value, err := validateCfg(rawValue)
 			if err != nil {
-				return wrapError(exitFailure, err)
+				return wrapError(exitValidation, err)
+			}
+
+			if isSensitive(key) {
+				rawValue = redacted
 			}
 
-			viper.Set(args[0], value)
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action:  "set",
+				Target:  opts.Viper.ConfigFileUsed(),
+				Changes: map[string]interface{}{key: rawValue},
+			}); ok {
+				return err
+			}
+
+			before := opts.Viper.Get(key)
 
-			if err := viper.WriteConfig(); err != nil {
+			if err := updateCfgFile(opts, func(v *viper.Viper) {
+				v.Set(key, value)
+			}); err != nil {
 				return wrapError(exitFailure, err)
 			}
 
+			recordAudit(cmd, opts, "config/"+key, before, value)
+
 			return nil
 		},
 	}
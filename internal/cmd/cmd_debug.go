@@ -0,0 +1,325 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	bundleHistoryEntries = 50
+	requestTraceFile     = "last_request.json"
+)
+
+// sensitiveKeys are the substrings of configuration keys and headers whose
+// values never leave the machine
+var sensitiveKeys = []string{
+	"token", "secret", "password", "authorization", "cookie", "webhook", "dsn", "pushgateway", "forward-to",
+}
+
+// requestTrace is the summary of the last HTTP request made, kept for
+// debug bundles
+type requestTrace struct {
+	Time            time.Time           `json:"time"`
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	Status          int                 `json:"status,omitempty"`
+	Error           string              `json:"error,omitempty"`
+	DurationMS      int64               `json:"duration_ms"`
+	RequestHeaders  map[string][]string `json:"request_headers"`
+	ResponseHeaders map[string][]string `json:"response_headers,omitempty"`
+}
+
+// newRequestTrace
+func newRequestTrace(req *http.Request, resp *http.Response, err error, start time.Time) *requestTrace {
+	t := &requestTrace{
+		Time:           start,
+		Method:         req.Method,
+		URL:            req.URL.Redacted(),
+		DurationMS:     time.Since(start).Milliseconds(),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+
+	if err != nil {
+		t.Error = err.Error()
+	}
+
+	if resp != nil {
+		t.Status = resp.StatusCode
+		t.ResponseHeaders = redactHeaders(resp.Header)
+	}
+
+	return t
+}
+
+// saveRequestTrace writes the last request of the command, if it made any,
+// to the state directory
+func saveRequestTrace(opts *Opts) {
+	opts.statsMu.Lock()
+	last := opts.stats.last
+	opts.statsMu.Unlock()
+
+	if last == nil {
+		return
+	}
+
+	dir, err := stateDir()
+	if err != nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(last, "", "  ")
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(filepath.Join(dir, requestTraceFile), data, 0o600)
+}
+
+// cmdDebug
+func cmdDebug(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Collect diagnostics for bug reports",
+	}
+
+	return initCmd(
+		cmd,
+		withOpts(opts),
+		withCmd(cmdDebugBundle(opts)),
+	)
+}
+
+// cmdDebugBundle
+func cmdDebugBundle(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Create a tarball with version, redacted configuration, logs and the last request",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := filepath.Join(
+				opts.Viper.GetString(optDir),
+				fmt.Sprintf("%s-debug-%s.tar.gz", cmdName, time.Now().Format("20060102-150405")),
+			)
+
+			if err := writeDebugBundle(opts, name); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			cmd.Printf("Wrote %s\n", name)
+			cmd.Println("Review it before attaching it to a bug report.")
+
+			return nil
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagDir(),
+		withOpts(opts),
+	)
+}
+
+// writeDebugBundle
+func writeDebugBundle(opts *Opts, name string) error {
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	version, err := json.MarshalIndent(versionInfo(opts), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	cfg, err := yaml.Marshal(redactSettings(opts.Viper.AllSettings()))
+	if err != nil {
+		return err
+	}
+
+	entries := map[string][]byte{
+		"version.json":  version,
+		"config.yaml":   cfg,
+		"history.jsonl": recentHistory(),
+	}
+
+	if dir, err := stateDir(); err == nil {
+		if data, err := os.ReadFile(filepath.Join(dir, requestTraceFile)); err == nil {
+			entries[requestTraceFile] = data
+		}
+	}
+
+	for _, log := range logFiles(opts) {
+		if data, err := os.ReadFile(log); err == nil {
+			entries[filepath.Join("logs", filepath.Base(log))] = data
+		}
+	}
+
+	paths := make([]string, 0, len(entries))
+	for path := range entries {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	now := time.Now()
+
+	for _, path := range paths {
+		data := entries[path]
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    filepath.ToSlash(filepath.Join(strings.TrimSuffix(filepath.Base(name), ".tar.gz"), path)),
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// recentHistory returns the last history entries as JSON lines
+func recentHistory() []byte {
+	entries, err := readHistory()
+	if err != nil {
+		return nil
+	}
+
+	if len(entries) > bundleHistoryEntries {
+		entries = entries[len(entries)-bundleHistoryEntries:]
+	}
+
+	var b strings.Builder
+
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			continue
+		}
+
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+
+	return []byte(b.String())
+}
+
+// logFiles returns the configured log file and its rotated backups
+func logFiles(opts *Opts) []string {
+	name := opts.Viper.GetString(cfgLogFile)
+	if name == "" {
+		return nil
+	}
+
+	if !filepath.IsAbs(name) {
+		dir, err := stateDir()
+		if err != nil {
+			return nil
+		}
+
+		name = filepath.Join(dir, name)
+	}
+
+	files := []string{name}
+	for i := 1; i <= logFileBackups; i++ {
+		files = append(files, fmt.Sprintf("%s.%d", name, i))
+	}
+
+	return files
+}
+
+// redactSettings replaces the values of sensitive keys, at any depth
+func redactSettings(settings map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(settings))
+
+	for key, value := range settings {
+		switch {
+		case isSensitive(key):
+			out[key] = redacted
+		case isMap(value):
+			out[key] = redactSettings(value.(map[string]interface{}))
+		default:
+			out[key] = value
+		}
+	}
+
+	return out
+}
+
+// redactHeaders
+func redactHeaders(h http.Header) map[string][]string {
+	out := make(map[string][]string, len(h))
+
+	for key, values := range h {
+		if isSensitive(key) {
+			out[key] = []string{redacted}
+			continue
+		}
+
+		out[key] = values
+	}
+
+	return out
+}
+
+// isSensitive
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMap
+func isMap(value interface{}) bool {
+	_, ok := value.(map[string]interface{})
+
+	return ok
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/build"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+const (
+	manSection = "1"
+)
+
+// cmdDocs
+func cmdDocs(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:    "docs",
+		Short:  "Generate reference documentation",
+		Hidden: true,
+	}
+
+	return initCmd(
+		cmd,
+		withOpts(opts),
+		withCmd(
+			cmdDocsMan(opts),
+			cmdDocsMarkdown(opts),
+		),
+	)
+}
+
+// cmdDocsMan
+func cmdDocsMan(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: "Generate man pages",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := docsDir(opts)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			header := &doc.GenManHeader{
+				Title:   strings.ToUpper(cmdName),
+				Section: manSection,
+				Source:  cmdName + " " + build.Version,
+			}
+
+			loadAllCmds(opts, cmd.Root())
+
+			if err := doc.GenManTree(cmd.Root(), header, dir); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagDir(),
+		withOpts(opts),
+	)
+}
+
+// cmdDocsMarkdown
+func cmdDocsMarkdown(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "markdown",
+		Short: "Generate markdown reference",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := docsDir(opts)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			loadAllCmds(opts, cmd.Root())
+
+			if err := doc.GenMarkdownTree(cmd.Root(), dir); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagDir(),
+		withOpts(opts),
+	)
+}
+
+// docsDir
+func docsDir(opts *Opts) (string, error) {
+	dir := opts.Viper.GetString(optDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
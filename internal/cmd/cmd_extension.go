@@ -0,0 +1,327 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+const (
+	extensionPrefix = cmdName + "-"
+	extensionsDir   = "extensions"
+	githubURL       = "https://github.com"
+)
+
+// cmdExtension
+func cmdExtension(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:     "extension",
+		Aliases: []string{"extensions", "ext"},
+		Short:   "Manage extensions",
+		Long: heredoc.Doc(`
+			Extensions are executables named opensdk-<name>, found either in the
+			extensions directory or on PATH, that run as "opensdk <name>".
+		`),
+	}
+
+	return initCmd(
+		cmd,
+		withOpts(opts),
+		withCmd(
+			cmdExtensionList(opts),
+			cmdExtensionInstall(opts),
+			cmdExtensionRemove(opts),
+		),
+	)
+}
+
+// cmdExtensionList
+func cmdExtensionList(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List installed extensions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			extensions, err := listExtensions()
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			names := make([]string, 0, len(extensions))
+			for name := range extensions {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				cmd.Printf("%s\t%s\n", name, extensions[name])
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// cmdExtensionInstall
+func cmdExtensionInstall(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "install <owner/repo>",
+		Short: "Install an extension from a git repository",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repo := strings.TrimSuffix(args[0], ".git")
+
+			name := path.Base(repo)
+			if !strings.HasPrefix(name, extensionPrefix) {
+				return newError(exitUsage, fmt.Sprintf(`extension repository must be named "%s<name>"`, extensionPrefix))
+			}
+
+			dir, err := extensionDir()
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			url := repo
+			if !strings.Contains(repo, "://") {
+				url = fmt.Sprintf("%s/%s.git", githubURL, repo)
+			}
+
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action:  "clone",
+				Target:  filepath.Join(dir, name),
+				Changes: map[string]interface{}{"url": url},
+			}); ok {
+				return err
+			}
+
+			clone := exec.Command("git", "clone", "--depth", "1", url, filepath.Join(dir, name))
+			clone.Stdout = opts.Stdout
+			clone.Stderr = opts.Stderr
+
+			if err := clone.Run(); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			recordAudit(cmd, opts, "extension/"+name, nil, url)
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// cmdExtensionRemove
+func cmdExtensionRemove(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an installed extension",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := extensionDir()
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			name := extensionPrefix + strings.TrimPrefix(args[0], extensionPrefix)
+			target := filepath.Join(dir, name)
+
+			if _, err := os.Stat(target); err != nil {
+				return newError(exitNotFound, fmt.Sprintf(`extension "%s" is not installed`, args[0]))
+			}
+
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action: "remove",
+				Target: target,
+			}); ok {
+				return err
+			}
+
+			if err := confirmTypedAction(opts, i18n.T("This removes the extension and its files."), name); err != nil {
+				return err
+			}
+
+			if err := os.RemoveAll(target); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			recordAudit(cmd, opts, "extension/"+name, target, nil)
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// extensionDir returns the directory where extensions are installed
+func extensionDir() (string, error) {
+	dir, err := profilesDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir = filepath.Join(dir, extensionsDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// listExtensions maps extension names to their executables. Installed
+// extensions take precedence over the ones found on PATH.
+func listExtensions() (map[string]string, error) {
+	extensions := make(map[string]string)
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+			if entry.IsDir() || !strings.HasPrefix(name, extensionPrefix) {
+				continue
+			}
+
+			name = strings.TrimPrefix(name, extensionPrefix)
+			if _, ok := extensions[name]; !ok {
+				extensions[name] = filepath.Join(dir, entry.Name())
+			}
+		}
+	}
+
+	dir, err := extensionDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		bin := filepath.Join(dir, entry.Name(), entry.Name())
+		if runtime.GOOS == "windows" {
+			bin += ".exe"
+		}
+
+		if _, err := os.Stat(bin); err == nil {
+			extensions[strings.TrimPrefix(entry.Name(), extensionPrefix)] = bin
+		}
+	}
+
+	return extensions, nil
+}
+
+// runExtension runs the first argument after the global flags as an
+// extension when it is not a known command. It reports whether an extension
+// was found.
+func runExtension(root *Cmd, opts *Opts, args []string) (bool, error) {
+	_, args = splitGlobalFlags(root, args)
+	if len(args) == 0 {
+		return false, nil
+	}
+
+	if found, _, err := root.Find(args); err == nil && found != root.Command {
+		return false, nil
+	}
+
+	extensions, err := listExtensions()
+	if err != nil {
+		return false, nil
+	}
+
+	bin, ok := extensions[args[0]]
+	if !ok {
+		return false, nil
+	}
+
+	initDispatchCfg(root, opts)
+
+	opts.log.Debug("running extension", "path", bin)
+
+	ext := exec.Command(bin, args[1:]...)
+	ext.Stdin = opts.Stdin
+	ext.Stdout = opts.Stdout
+	ext.Stderr = opts.Stderr
+	ext.Dir = opts.WorkDir
+	ext.Env = append(os.Environ(), extensionEnv(opts)...)
+
+	if err := ext.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return true, wrapError(exitErr.ExitCode(), err)
+		}
+
+		return true, wrapError(exitFailure, err)
+	}
+
+	return true, nil
+}
+
+// extensionEnv exposes the resolved configuration to extensions
+func extensionEnv(opts *Opts) []string {
+	name := opts.Viper.GetString(optProfile)
+	if name == "" {
+		name = defaultProfile
+	}
+
+	env := []string{
+		fmt.Sprintf("%s=%s", envProfile, name),
+	}
+
+	if file := opts.Viper.ConfigFileUsed(); file != "" {
+		env = append(env, fmt.Sprintf("%s=%s", envCfgFile, file))
+	}
+
+	for _, key := range []string{optAccount, optAccessToken, optBaseURL, optSandbox} {
+		if opts.Viper.IsSet(key) {
+			env = append(env, fmt.Sprintf("%s=%s", convertFlagToEnv(key), opts.Viper.GetString(key)))
+		}
+	}
+
+	return env
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// testExtension prints the profile and the arguments it gets, and fails
+// with status 3 when the first argument is fail
+const testExtension = `#!/bin/sh
+echo "$OPENSDK_PROFILE $*"
+if [ "$1" = fail ]; then
+	exit 3
+fi
+`
+
+// installTestExtension puts the hello extension in the PATH
+func installTestExtension(t *testing.T) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("extensions are shell scripts")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, extensionPrefix+"hello"), []byte(testExtension), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestRunExtension(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   string
+		ok     bool
+		code   int
+		stdout string
+	}{
+		{"extension", "hello a b", true, exitSuccess, "main a b\n"},
+		{"global flags", "--profile prod hello a", true, exitSuccess, "prod a\n"},
+		{"exit status", "hello fail", true, 3, "main fail\n"},
+		{"command", "version", false, exitSuccess, ""},
+		{"unknown", "nosuch", false, exitSuccess, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installTestExtension(t)
+
+			var stdout bytes.Buffer
+
+			root, opts := newTestTree(t, "", &stdout, io.Discard)
+
+			ok, err := runExtension(root, opts, strings.Fields(tt.args))
+			if ok != tt.ok {
+				t.Fatalf("got ok %v, want %v", ok, tt.ok)
+			}
+
+			if code := ExitCode(err); code != tt.code {
+				t.Errorf("got exit code %d, want %d: %v", code, tt.code, err)
+			}
+
+			if stdout.String() != tt.stdout {
+				t.Errorf("got output %q, want %q", stdout.String(), tt.stdout)
+			}
+		})
+	}
+}
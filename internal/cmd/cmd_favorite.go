@@ -0,0 +1,254 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	cfgFavorites   = "favorites"
+	favoritePrefix = "@"
+	optName        = "name"
+)
+
+var favoriteName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// cmdFavorite
+func cmdFavorite(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:     "favorite",
+		Aliases: []string{"favorites", "fav"},
+		Short:   "Manage shortcuts for frequently used names and IDs",
+		Long: heredoc.Doc(`
+			Favorites are shortcuts for names and IDs you type often. Once
+			saved, @name can be used in place of the value in any argument or
+			flag, as in "opensdk foo --account @acme". Use @@ for a literal @.
+		`),
+	}
+
+	return initCmd(
+		cmd,
+		withOpts(opts),
+		withCmd(
+			cmdFavoriteAdd(opts),
+			cmdFavoriteList(opts),
+			cmdFavoriteRemove(opts),
+		),
+	)
+}
+
+// cmdFavoriteAdd
+func cmdFavoriteAdd(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "add <value>",
+		Short: "Save a favorite",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value := args[0]
+
+			name := opts.Viper.GetString(optName)
+			if name == "" {
+				name = strings.SplitN(value, ".", 2)[0]
+			}
+
+			if !favoriteName.MatchString(name) {
+				return newError(exitUsage, fmt.Sprintf(`invalid favorite name "%s": use letters, digits, "-" and "_"`, name))
+			}
+
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action:  "set",
+				Target:  opts.Viper.ConfigFileUsed(),
+				Changes: map[string]interface{}{cfgFavorites + "." + name: value},
+			}); ok {
+				return err
+			}
+
+			var before interface{}
+			if old, ok := opts.Viper.GetStringMapString(cfgFavorites)[name]; ok {
+				before = old
+			}
+
+			if err := updateCfgFile(opts, func(v *viper.Viper) {
+				favorites := v.GetStringMapString(cfgFavorites)
+				favorites[name] = value
+
+				v.Set(cfgFavorites, favorites)
+			}); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			recordAudit(cmd, opts, "favorite/"+name, before, value)
+
+			cmd.Printf("Saved %s%s for %s\n", favoritePrefix, name, value)
+
+			return nil
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagName(),
+		withOpts(opts),
+	)
+}
+
+// cmdFavoriteList
+func cmdFavoriteList(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List favorites",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			favorites := opts.Viper.GetStringMapString(cfgFavorites)
+
+			names := make([]string, 0, len(favorites))
+			for name := range favorites {
+				names = append(names, name)
+			}
+
+			sort.Strings(names)
+
+			for _, name := range names {
+				cmd.Printf("%s%s: %s\n", favoritePrefix, name, favorites[name])
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// cmdFavoriteRemove
+func cmdFavoriteRemove(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a favorite",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimPrefix(args[0], favoritePrefix)
+
+			favorites := opts.Viper.GetStringMapString(cfgFavorites)
+			if _, ok := favorites[name]; !ok {
+				return newError(exitNotFound, fmt.Sprintf(`no such favorite "%s"`, name))
+			}
+
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action:  "delete",
+				Target:  opts.Viper.ConfigFileUsed(),
+				Changes: map[string]interface{}{cfgFavorites + "." + name: nil},
+			}); ok {
+				return err
+			}
+
+			if err := confirmAction(opts, i18n.T(`Remove favorite "%s"?`, name)); err != nil {
+				return err
+			}
+
+			if err := updateCfgFile(opts, func(v *viper.Viper) {
+				favorites := v.GetStringMapString(cfgFavorites)
+				delete(favorites, name)
+
+				v.Set(cfgFavorites, favorites)
+			}); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			recordAudit(cmd, opts, "favorite/"+name, favorites[name], nil)
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// expandFavorites replaces @name in the arguments and the string flags of
+// cmd with the saved values. @@ stands for a literal @.
+func expandFavorites(cmd *cobra.Command, opts *Opts, args []string) error {
+	if strings.HasPrefix(cmd.Name(), cobra.ShellCompRequestCmd) || strings.HasPrefix(hookKey(cmd), "favorite") {
+		return nil
+	}
+
+	favorites := opts.Viper.GetStringMapString(cfgFavorites)
+
+	expand := func(s string) (string, error) {
+		if strings.HasPrefix(s, favoritePrefix+favoritePrefix) {
+			return s[1:], nil
+		}
+
+		name := strings.TrimPrefix(s, favoritePrefix)
+		if name == s || !favoriteName.MatchString(name) {
+			return s, nil
+		}
+
+		value, ok := favorites[name]
+		if !ok {
+			return "", wrapError(exitUsage, withHint(
+				fmt.Errorf(`no favorite named "%s"`, name),
+				fmt.Sprintf("run `%s favorite list` to see the saved ones", cmdName),
+			))
+		}
+
+		return value, nil
+	}
+
+	for i, arg := range args {
+		value, err := expand(arg)
+		if err != nil {
+			return err
+		}
+
+		args[i] = value
+	}
+
+	var flagErr error
+
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if flagErr != nil || f.Value.Type() != "string" {
+			return
+		}
+
+		value, err := expand(f.Value.String())
+		if err != nil {
+			flagErr = err
+			return
+		}
+
+		if value != f.Value.String() {
+			flagErr = f.Value.Set(value)
+		}
+	})
+
+	return flagErr
+}
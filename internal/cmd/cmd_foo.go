@@ -17,11 +17,11 @@
 package cmd
 
 import (
-	"github.com/MakeNowJust/heredoc/v2"
+	"fmt"
+
 	"github.com/edsonmichaque/opensdk-cli/internal/cmd/formatter"
 	"github.com/edsonmichaque/opensdk-cli/internal/config"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // cmdFoo
@@ -29,54 +29,67 @@ func cmdFoo(opts *Opts) *Cmd {
 	cmd := &cobra.Command{
 		Use:   "foo",
 		Short: "List accounts",
-		Example: heredoc.Doc(`
-			opensdk foo
-			opensdk foo --output=json
-			opensdk foo --output=yaml
-			opensdk foo --output=json --query="[].id"
-		`),
-		Args: cobra.NoArgs,
+		Args:  cobra.NoArgs,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return cmdPreRun(
 				func() error {
-					return viper.BindPFlags(cmd.Flags())
+					return opts.Viper.BindPFlags(cmd.Flags())
 				},
 				func() error {
-					return flagContains(
-						optOutput,
-						[]string{
-							outputJSON,
-							outputYAML,
-							outputTable,
-						},
+					return validateFlags(
+						cmd, opts,
+						flagEnum(optOutput, outputJSON, outputYAML, outputTable),
+						flagsMutuallyExclusive(optProfiles, optAllProfiles),
 					)
 				},
 			)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, err := config.Init()
+			profiles, err := fanOutProfiles(cmd, opts)
 			if err != nil {
-				return wrapError(exitFailure, err)
+				return err
 			}
 
-			fooList := formatter.FooList{
-				formatter.Foo{
-					ID:   1,
-					Name: "First Name",
-					Age:  "19",
-				},
-				formatter.Foo{
-					ID:   2,
-					Name: "First Name",
-					Age:  "19",
-				},
+			var fooList formatter.FooList
+
+			if profiles == nil {
+				if fooList, err = listFoo(opts); err != nil {
+					return err
+				}
+			}
+
+			for _, name := range sortedProfiles(profiles) {
+				items, err := listFoo(profiles[name])
+				if err != nil {
+					return fmt.Errorf("profile %s: %w", name, err)
+				}
+
+				for i := range items {
+					items[i].Profile = name
+				}
+
+				fooList = append(fooList, items...)
+			}
+
+			fooList, err = filterItems(opts, fooList)
+			if err != nil {
+				return err
+			}
+
+			if err := failOnEmpty(opts, len(fooList)); err != nil {
+				return err
 			}
 
+			opts.statsItems(len(fooList))
+
+			githubSummary(cmd, opts, fooList)
+
 			fooOutput, err := formatter.Format(
 				fooList, &formatter.Opts{
 					Output: formatter.Output(
-						viper.GetString(optOutput),
+						opts.Viper.GetString(optOutput),
 					),
+					Query: opts.Viper.GetString(optQuery),
 				},
 			)
 			if err != nil {
@@ -95,6 +108,29 @@ func cmdFoo(opts *Opts) *Cmd {
 		cmd,
 		withFlagOutput(outputTable),
 		withFlagQuery(),
+		withFlagFilter(),
+		withFlagFailOnEmpty(),
+		withFlagFanOut(),
 		withOpts(opts),
 	)
 }
+
+// listFoo
+func listFoo(opts *Opts) (formatter.FooList, error) {
+	if _, err := config.Init(opts.Viper); err != nil {
+		return nil, wrapError(exitFailure, cfgHint(err))
+	}
+
+	return formatter.FooList{
+		formatter.Foo{
+			ID:   1,
+			Name: "First Name",
+			Age:  "19",
+		},
+		formatter.Foo{
+			ID:   2,
+			Name: "First Name",
+			Age:  "19",
+		},
+	}, nil
+}
@@ -0,0 +1,296 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/cmd/formatter"
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cfgHistory     = "history"
+	historyLogFile = "history.jsonl"
+	historyCmdName = "history"
+)
+
+// cmdHistory
+func cmdHistory(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   historyCmdName,
+		Short: "Show commands run from this machine",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdPreRun(
+				func() error {
+					return opts.Viper.BindPFlags(cmd.Flags())
+				},
+				func() error {
+					return validateFlags(cmd, opts, flagEnum(optOutput, outputJSON, outputYAML, outputTable))
+				},
+			)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printHistory(cmd, opts)
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagOutput(outputTable),
+		withFlagQuery(),
+		withFlagFilter(),
+		withFlagFailOnEmpty(),
+		withOpts(opts),
+		withCmd(
+			cmdHistoryReplay(opts),
+			cmdHistoryExport(opts),
+		),
+	)
+}
+
+// cmdHistoryReplay
+func cmdHistoryReplay(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "replay <id>",
+		Short: "Run a command from history again",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return wrapError(exitUsage, err)
+			}
+
+			entries, err := readHistory()
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			if id < 1 || id > len(entries) {
+				return newError(exitNotFound, fmt.Sprintf("no history entry %d", id))
+			}
+
+			entry := entries[id-1]
+
+			for _, arg := range entry.Args {
+				if strings.Contains(arg, redacted) {
+					return newError(exitFailure, "history entry contains redacted values and cannot be replayed")
+				}
+			}
+
+			if err := confirmAction(opts, i18n.T("Run `%s %s`?", cmdName, strings.Join(entry.Args, " "))); err != nil {
+				return err
+			}
+
+			replay := opts.fork()
+
+			return execRoot(cmdRoot(replay), replay, entry.Args)
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// cmdHistoryExport
+func cmdHistoryExport(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export command history for audits",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdPreRun(
+				func() error {
+					return opts.Viper.BindPFlags(cmd.Flags())
+				},
+				func() error {
+					return validateFlags(cmd, opts, flagEnum(optOutput, outputJSON, outputYAML))
+				},
+			)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printHistory(cmd, opts)
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagOutput(outputJSON),
+		withFlagFilter(),
+		withFlagFailOnEmpty(),
+		withOpts(opts),
+	)
+}
+
+// printHistory
+func printHistory(cmd *cobra.Command, opts *Opts) error {
+	entries, err := readHistory()
+	if err != nil {
+		return wrapError(exitFailure, err)
+	}
+
+	entries, err = filterItems(opts, entries)
+	if err != nil {
+		return err
+	}
+
+	if err := failOnEmpty(opts, len(entries)); err != nil {
+		return err
+	}
+
+	githubSummary(cmd, opts, entries)
+
+	output, err := formatter.Format(
+		entries, &formatter.Opts{
+			Output: formatter.Output(opts.Viper.GetString(optOutput)),
+			Query:  opts.Viper.GetString(optQuery),
+		},
+	)
+	if err != nil {
+		return wrapError(exitFailure, err)
+	}
+
+	if err := cmdPrint(cmd, output); err != nil {
+		return wrapError(exitFailure, err)
+	}
+
+	return nil
+}
+
+// historyPath
+func historyPath() (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, historyLogFile), nil
+}
+
+// readHistory
+func readHistory() (formatter.HistoryList, error) {
+	path, err := historyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return formatter.HistoryList{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := formatter.HistoryList{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry formatter.HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+
+		entry.ID = len(entries) + 1
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// recordHistory appends an invocation to the history log. Failing to record
+// history never fails the command.
+func recordHistory(cmd *cobra.Command, opts *Opts, args []string, err error) {
+	if cmd == nil || cmd.Hidden || strings.HasPrefix(cmd.Name(), cobra.ShellCompRequestCmd) {
+		return
+	}
+
+	if strings.HasPrefix(hookKey(cmd), historyCmdName) {
+		return
+	}
+
+	if opts.Viper.IsSet(cfgHistory) && !opts.Viper.GetBool(cfgHistory) {
+		return
+	}
+
+	path, pathErr := historyPath()
+	if pathErr != nil {
+		return
+	}
+
+	data, jsonErr := json.Marshal(formatter.HistoryEntry{
+		Time:     time.Now().UTC(),
+		Command:  hookKey(cmd),
+		Args:     redactArgs(args),
+		WorkDir:  opts.WorkDir,
+		ExitCode: ExitCode(err),
+	})
+	if jsonErr != nil {
+		return
+	}
+
+	file, openErr := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if openErr != nil {
+		return
+	}
+	defer file.Close()
+
+	_, _ = file.Write(append(data, '\n'))
+}
+
+// redactArgs hides secrets passed on the command line, as the value of a
+// sensitive flag or of a sensitive setting given to config set
+func redactArgs(args []string) []string {
+	redactedArgs := make([]string, len(args))
+	copy(redactedArgs, args)
+
+	for i, arg := range redactedArgs {
+		if name := strings.TrimPrefix(arg, "--"); name != arg {
+			name, _, hasValue := strings.Cut(name, "=")
+
+			switch {
+			case !isSensitive(name):
+			case hasValue:
+				redactedArgs[i] = "--" + name + "=" + redacted
+			case i+1 < len(redactedArgs):
+				redactedArgs[i+1] = redacted
+			}
+
+			continue
+		}
+
+		if i > 0 && redactedArgs[i-1] == "set" && isSensitive(arg) && i+1 < len(redactedArgs) {
+			redactedArgs[i+1] = redacted
+		}
+	}
+
+	return redactedArgs
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"no secrets", "foo --output json", "foo --output json"},
+		{"flag value", "foo --access-token abc", "foo --access-token " + redacted},
+		{"flag with equals", "foo --access-token=abc", "foo --access-token=" + redacted},
+		{"flag last", "foo --access-token", "foo --access-token"},
+		{"config set", "config set access-token abc", "config set access-token " + redacted},
+		{"config set other", "config set account 1234", "config set account 1234"},
+		{"webhook url", "webhooks listen --forward-to http://localhost:8080", "webhooks listen --forward-to " + redacted},
+		{"case", "config set Client-Secret abc", "config set Client-Secret " + redacted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := strings.Fields(tt.args)
+			orig := append([]string{}, args...)
+
+			if got, want := redactArgs(args), strings.Fields(tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %q, want %q", got, want)
+			}
+
+			if !reflect.DeepEqual(args, orig) {
+				t.Errorf("args changed to %q", args)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/edsonmichaque/opensdk-cli/internal/config"
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// cmdInit
+func cmdInit(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create your first profile",
+		Long: heredoc.Doc(`
+			Interactively create a profile: pick the environment, enter your
+			credentials and default account, choose a default output format and
+			write the configuration file.
+		`),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Viper.GetBool(optNoInteractive) {
+				return newError(exitUsage, "init is interactive; use config set in non-interactive mode")
+			}
+
+			cfg, err := config.LoadWithValidation(opts.Viper, false)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			cfg, err = execInitPrompt(cfg)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			dir, err := profilesDir()
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			target := filepath.Join(dir, fmt.Sprintf("%s.%s", opts.Viper.GetString(optProfile), cfgFmtYAML))
+
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action: "write",
+				Target: target,
+				Changes: map[string]interface{}{
+					optAccount:     cfg.Account,
+					optAccessToken: redacted,
+					optBaseURL:     cfg.BaseURL,
+					optSandbox:     cfg.Sandbox,
+					optOutput:      cfg.Output,
+				},
+			}); ok {
+				return err
+			}
+
+			if _, err := os.Stat(target); err == nil {
+				if err := confirmAction(opts, i18n.T("Overwrite %s?", target)); err != nil {
+					return err
+				}
+			}
+
+			if err := os.MkdirAll(dir, 0o700); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			if err := writeCfg(cfg, target); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			recordAudit(cmd, opts, "profile/"+opts.Viper.GetString(optProfile), nil, target)
+
+			cmd.Printf("Profile %q written to %s\n", opts.Viper.GetString(optProfile), target)
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// execInitPrompt
+func execInitPrompt(c *config.Config) (*config.Config, error) {
+	env := envProd
+	if c.Sandbox {
+		env = envSandbox
+	}
+
+	res, err := execPrompt(execEnvPrompt(env))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.Config{
+		Sandbox: res.GetString(promptEnv) == envSandbox,
+	}
+
+	runners := []promptRunner{
+		execAccessTokenPrompt(c.AccessToken),
+		execAccountPrompt(c.Account),
+		execOutputPrompt(outputTable),
+	}
+
+	if res.GetString(promptEnv) == envDev {
+		runners = append(runners, execBaseURLPrompt(c.BaseURL))
+	}
+
+	res, err = execPrompt(runners...)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.AccessToken = res.GetString(optAccessToken)
+	cfg.Account = res.GetString(optAccount)
+	cfg.BaseURL = res.GetString(optBaseURL)
+	cfg.Output = res.GetString(optOutput)
+
+	return &cfg, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/edsonmichaque/opensdk-cli/internal/cmd/formatter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	rateLimitFile    = "ratelimit.json"
+	rateLimitWindow  = 24 * time.Hour
+	headerRateLimit  = "X-RateLimit-Limit"
+	headerRateRemain = "X-RateLimit-Remaining"
+	headerRateReset  = "X-RateLimit-Reset"
+)
+
+// rateLimit is the rate limit reported by the last API response
+type rateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// rateLimitUsage is the number of API calls made by one command
+type rateLimitUsage struct {
+	Time     time.Time `json:"time"`
+	Requests int       `json:"requests"`
+}
+
+// rateLimitCache is kept in the state directory across runs
+type rateLimitCache struct {
+	rateLimit
+	Updated time.Time        `json:"updated"`
+	Usage   []rateLimitUsage `json:"usage"`
+}
+
+// parseRateLimit reads the rate limit headers of resp
+func parseRateLimit(resp *http.Response) *rateLimit {
+	limit, err := strconv.Atoi(resp.Header.Get(headerRateLimit))
+	if err != nil {
+		return nil
+	}
+
+	rl := &rateLimit{Limit: limit}
+	rl.Remaining, _ = strconv.Atoi(resp.Header.Get(headerRateRemain))
+
+	if reset, err := strconv.ParseInt(resp.Header.Get(headerRateReset), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0).UTC()
+	}
+
+	return rl
+}
+
+// readRateLimitCache
+func readRateLimitCache() (*rateLimitCache, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, rateLimitFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var cache rateLimitCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+
+	return &cache, nil
+}
+
+// saveRateLimit adds the API calls of the command to the cache, along with
+// the last rate limit reported. Usage older than a day is dropped.
+func saveRateLimit(opts *Opts) {
+	opts.statsMu.Lock()
+	requests, last := opts.stats.requests, opts.stats.rateLimit
+	opts.statsMu.Unlock()
+
+	if requests == 0 {
+		return
+	}
+
+	cache, err := readRateLimitCache()
+	if err != nil {
+		cache = &rateLimitCache{}
+	}
+
+	now := time.Now().UTC()
+
+	if last != nil {
+		cache.rateLimit = *last
+		cache.Updated = now
+	}
+
+	usage := []rateLimitUsage{}
+	for _, u := range cache.Usage {
+		if now.Sub(u.Time) < rateLimitWindow {
+			usage = append(usage, u)
+		}
+	}
+
+	cache.Usage = append(usage, rateLimitUsage{Time: now, Requests: requests})
+
+	dir, err := stateDir()
+	if err != nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(filepath.Join(dir, rateLimitFile), data, 0o600)
+}
+
+// cmdRateLimit
+func cmdRateLimit(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:     "ratelimit",
+		Aliases: []string{"rate-limit"},
+		Short:   "Inspect the API rate limit",
+	}
+
+	return initCmd(
+		cmd,
+		withOpts(opts),
+		withCmd(cmdRateLimitStatus(opts)),
+	)
+}
+
+// cmdRateLimitStatus
+func cmdRateLimitStatus(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the remaining requests and recent consumption",
+		Long: heredoc.Doc(`
+			Show the rate limit reported by the last API response and the
+			requests made from this machine in the last hour and day. No API
+			call is made, so checking the status does not use up requests.
+		`),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdPreRun(
+				func() error {
+					return opts.Viper.BindPFlags(cmd.Flags())
+				},
+				func() error {
+					return validateFlags(cmd, opts, flagEnum(optOutput, outputText, outputJSON, outputYAML))
+				},
+			)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cache, err := readRateLimitCache()
+			if errors.Is(err, os.ErrNotExist) || err == nil && cache.Updated.IsZero() {
+				return wrapError(exitNotFound, withHint(
+					errors.New("no rate limit recorded yet"),
+					"run a command that calls the API first",
+				))
+			}
+
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			output, err := formatter.Format(
+				rateLimitStatus(cache, time.Now()), &formatter.Opts{
+					Output: formatter.Output(opts.Viper.GetString(optOutput)),
+					Query:  opts.Viper.GetString(optQuery),
+				},
+			)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			if err := cmdPrint(cmd, output); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagOutput(outputText),
+		withFlagQuery(),
+		withOpts(opts),
+	)
+}
+
+// rateLimitStatus sums up cache at now. Once the reset time has passed the
+// whole limit is available again.
+func rateLimitStatus(cache *rateLimitCache, now time.Time) formatter.RateLimit {
+	status := formatter.RateLimit{
+		Limit:     cache.Limit,
+		Remaining: cache.Remaining,
+		Reset:     cache.Reset,
+		Updated:   cache.Updated,
+	}
+
+	if !cache.Reset.IsZero() && now.After(cache.Reset) {
+		status.Remaining = cache.Limit
+	}
+
+	for _, u := range cache.Usage {
+		age := now.Sub(u.Time)
+
+		if age < time.Hour {
+			status.LastHour += u.Requests
+		}
+
+		if age < rateLimitWindow {
+			status.LastDay += u.Requests
+		}
+	}
+
+	return status
+}
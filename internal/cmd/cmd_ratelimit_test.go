@@ -0,0 +1,75 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestSaveRateLimitAPIOnly checks that the rate limit and the usage come
+// from the API calls, not from other services sending the same headers
+func TestSaveRateLimitAPIOnly(t *testing.T) {
+	t.Setenv(envStateHome, t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, remaining := "60", "59"
+		if r.URL.Path == "/api" {
+			limit, remaining = "5000", "4990"
+		}
+
+		w.Header().Set(headerRateLimit, limit)
+		w.Header().Set(headerRateRemain, remaining)
+	}))
+	defer server.Close()
+
+	opts := &Opts{Viper: newViper(), runState: newRunState()}
+	opts.startStats()
+
+	for _, tt := range []struct {
+		client *http.Client
+		path   string
+	}{
+		{opts.httpClient(), "/api"},
+		{opts.externalClient(), "/repos/releases/latest"},
+	} {
+		resp, err := tt.client.Get(server.URL + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	saveRateLimit(opts)
+
+	cache, err := readRateLimitCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cache.Limit != 5000 || cache.Remaining != 4990 {
+		t.Errorf("got limit %d, remaining %d, want 5000, 4990", cache.Limit, cache.Remaining)
+	}
+
+	if len(cache.Usage) != 1 || cache.Usage[0].Requests != 1 {
+		t.Errorf("got usage %+v, want one command with 1 request", cache.Usage)
+	}
+}
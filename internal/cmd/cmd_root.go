@@ -17,49 +17,70 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+	"github.com/edsonmichaque/opensdk-cli/internal/logging"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
-var (
-	configFile string
-	profile    string
-)
-
 const (
+	cfgLogFile        = "log-file"
 	cmdName           = "opensdk"
+	defaultBaseURL    = "https://example.com"
+	defaultLogFormat  = "text"
+	defaultLogLevel   = "warn"
 	defaultProfile    = "main"
 	envCfgFile        = "OPENSDK_CONFIG_FILE"
 	envCfgHome        = "XDG_CONFIG_HOME"
+	envLocalAppData   = "LocalAppData"
+	envProgramData    = "ProgramData"
 	envDev            = "DEV"
 	envPrefix         = "OPENSDK"
 	envProd           = "PROD"
 	envProfile        = "OPENSDK_PROFILE"
 	envSandbox        = "SANDBOX"
+	envStateHome      = "XDG_STATE_HOME"
+	logFileBackups    = 3
+	logFileMaxSize    = 5 << 20
 	optAccessToken    = "access-token"
 	optAccount        = "account"
 	optBaseURL        = "base-url"
 	optCollaboratorID = "collaborator-id"
 	optConfigFile     = "config-file"
 	optConfirm        = "confirm"
+	optCopy           = "copy"
+	optDir            = "dir"
 	optDomain         = "domain"
+	optDryRun         = "dry-run"
+	optForce          = "force"
 	optFormat         = "format"
 	optFromFile       = "from-file"
+	optLogFormat      = "log-format"
+	optLogLevel       = "log-level"
 	optOutput         = "output"
 	optPage           = "page"
 	optPerPage        = "per-page"
 	optProfile        = "profile"
 	optNoInteractive  = "no-interactive"
+	optNotify         = "notify"
 	optQuery          = "query"
 	optRecordID       = "record-id"
 	optSandbox        = "sandbox"
+	optSince          = "since"
+	optStats          = "stats"
 	outputJSON        = "json"
 	outputTable       = "table"
 	outputText        = "text"
@@ -67,12 +88,6 @@ const (
 	pathConfigFile    = "/etc/opensdk"
 )
 
-// init
-func init() {
-	cobra.OnInitialize(initCfg)
-	viperBindFlags()
-}
-
 // Run
 func Run() error {
 	return run()
@@ -90,7 +105,125 @@ func run() error {
 
 // runWithOpts
 func runWithOpts(opts *Opts) error {
-	return cmdRoot(opts).Execute()
+	root := cmdRoot(opts)
+
+	args, err := expandAlias(root, opts, os.Args[1:])
+	if err != nil {
+		return wrapError(exitFailure, err)
+	}
+
+	if ok, err := runExtension(root, opts, args); ok {
+		return err
+	}
+
+	return execRoot(root, opts, args)
+}
+
+// splitGlobalFlags parses the global flags given before the command name in
+// args, such as --profile, into the flags of root. It returns those flags and
+// the arguments from the command name on, which are empty when args has no
+// command name or the flags cannot be parsed.
+func splitGlobalFlags(root *Cmd, args []string) ([]string, []string) {
+	flags := pflag.NewFlagSet(cmdName, pflag.ContinueOnError)
+	flags.AddFlagSet(root.PersistentFlags())
+	flags.SetInterspersed(false)
+	flags.SetOutput(io.Discard)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.Usage = func() {}
+
+	if err := flags.Parse(args); err != nil {
+		return args, nil
+	}
+
+	rest := flags.Args()
+
+	return args[:len(args)-len(rest)], rest
+}
+
+// initDispatchCfg loads the configuration selected by the global flags, for
+// the aliases and the extensions resolved before root runs
+func initDispatchCfg(root *Cmd, opts *Opts) {
+	if opts.initialized {
+		return
+	}
+
+	if err := opts.Viper.BindPFlags(root.PersistentFlags()); err != nil {
+		opts.log.Warn("could not bind the global flags", "error", err)
+	}
+
+	initCfg(opts)
+}
+
+// execRoot runs root with args, reports the error, if any, and records the
+// invocation in the history
+func execRoot(root *Cmd, opts *Opts, args []string) error {
+	root.SetArgs(args)
+	loadCmds(opts, root.Command, args)
+
+	defer reportPanic(opts)
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	check := startVersionCheck(opts)
+	start := time.Now()
+	opts.startStats()
+	ctx, span := startCmdSpan(ctx, opts)
+
+	c, err := root.ExecuteContextC(ctx)
+	if err != nil && ctx.Err() != nil {
+		err = wrapError(exitInterrupted, err)
+	}
+
+	if err != nil {
+		printError(c, opts, err)
+	}
+
+	reportError(c, opts, err)
+	endCmdSpan(span, c, opts, err)
+	printStats(opts)
+	saveRequestTrace(opts)
+	saveRateLimit(opts)
+
+	if c != nil {
+		kv := []interface{}{
+			"command", hookKey(c),
+			"exit_code", ExitCode(err),
+			"duration", time.Since(start).Round(time.Millisecond),
+		}
+
+		if err != nil {
+			kv = append(kv, "error", err)
+		}
+
+		opts.log.Info("command finished", kv...)
+	}
+
+	reportGitHub(c, opts, err)
+	pushMetrics(c, opts, err, time.Since(start))
+	telemetry := sendTelemetry(c, opts, err, time.Since(start))
+	notifyCompletion(c, opts, err, time.Since(start))
+	check.notify(c, opts)
+
+	recordHistory(c, opts, args, err)
+	telemetry.wait()
+	check.wait()
+
+	return err
+}
+
+// signalContext returns a context canceled on SIGINT or SIGTERM, so that
+// requests in flight are aborted and the command returns through the usual
+// path instead of dying mid-write. A second signal terminates the process.
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	return ctx, stop
 }
 
 // cmdRoot
@@ -98,75 +231,175 @@ func cmdRoot(opts *Opts) *Cmd {
 	cmd := &cobra.Command{
 		Use: cmdName,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return viper.BindPFlags(cmd.PersistentFlags())
+			return opts.Viper.BindPFlags(cmd.PersistentFlags())
 		},
-		SilenceUsage: true,
+		SilenceUsage:  true,
+		SilenceErrors: true,
 	}
 
 	return initCmd(
 		cmd,
-		withCmd(cmdFoo(opts)),
-		withCmd(cmdBar(opts)),
-		withCmd(cmdCfg(opts)),
-		withCmd(cmdVersion(opts)),
-		withFlagsGlobal(),
+		withLazyCmd(opts, "favorite", func() *Cmd { return cmdFavorite(opts) }, "favorites", "fav"),
+		withLazyCmd(opts, "foo", func() *Cmd { return cmdFoo(opts) }),
+		withLazyCmd(opts, "bar", func() *Cmd { return cmdBar(opts) }),
+		withLazyCmd(opts, "config", func() *Cmd { return cmdCfg(opts) }),
+		withLazyCmd(opts, "version", func() *Cmd { return cmdVersion(opts) }),
+		withLazyCmd(opts, "completion", func() *Cmd { return cmdCompletion(opts) }),
+		withLazyCmd(opts, "docs", func() *Cmd { return cmdDocs(opts) }),
+		withLazyCmd(opts, "shell", func() *Cmd { return cmdShell(opts) }),
+		withLazyCmd(opts, "alias", func() *Cmd { return cmdAlias(opts) }),
+		withLazyCmd(opts, "extension", func() *Cmd { return cmdExtension(opts) }, "extensions", "ext"),
+		withLazyCmd(opts, historyCmdName, func() *Cmd { return cmdHistory(opts) }),
+		withLazyCmd(opts, "init", func() *Cmd { return cmdInit(opts) }),
+		withLazyCmd(opts, "examples", func() *Cmd { return cmdExamplesTopics(opts) }),
+		withLazyCmd(opts, "upgrade", func() *Cmd { return cmdUpgrade(opts) }),
+		withLazyCmd(opts, "telemetry", func() *Cmd { return cmdTelemetry(opts) }),
+		withLazyCmd(opts, "debug", func() *Cmd { return cmdDebug(opts) }),
+		withLazyCmd(opts, "audit", func() *Cmd { return cmdAudit(opts) }),
+		withLazyCmd(opts, "ratelimit", func() *Cmd { return cmdRateLimit(opts) }, "rate-limit"),
+		withLazyCmd(opts, "status", func() *Cmd { return cmdStatus(opts) }),
+		withLazyCmd(opts, "webhooks", func() *Cmd { return cmdWebhooks(opts) }, "webhook"),
+		withFlagsGlobal(opts),
+		withHooks(opts),
+		withSilentSuccess(opts),
+		withInit(opts),
+		withLocale(opts),
+		withOpts(opts),
+		withLogger(opts),
+		withExamples(),
+		withSuggestions(),
 	)
 }
 
-// initCfg
-func initCfg() {
-	var (
-		cfgFile string
-		cfgName string
-		cfgDir  string
-	)
+// withInit loads the configuration and sets up logging before the command
+// being executed runs
+func withInit(opts *Opts) cmdOption {
+	return func(cmd *cobra.Command) {
+		preRun := cmd.PersistentPreRunE
+
+		cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+			if !opts.initialized {
+				initLog(opts)
+				initColor(opts)
+				initCfg(opts)
+				initLocale(opts)
+				initLogFile(opts)
+				opts.initialized = true
+			}
+
+			opts.statsSetupDone()
+
+			if err := checkStrictCfg(cmd, opts); err != nil {
+				return err
+			}
+
+			if err := expandFavorites(cmd, opts, args); err != nil {
+				return err
+			}
 
-	var err error
-	if configFile != "" {
-		cfgFile = configFile
+			if preRun != nil {
+				return preRun(cmd, args)
+			}
+
+			return nil
+		}
 	}
+}
 
-	if path := os.Getenv(envCfgFile); path != "" && configFile == "" {
-		cfgFile = path
+// initCfg reads the configuration file given by --config-file or
+// OPENSDK_CONFIG_FILE, or else the profile file in the configuration
+// directory, falling back to the system-wide directory
+func initCfg(opts *Opts) {
+	cfgFile := opts.ConfigFile
+	if cfgFile == "" {
+		cfgFile = os.Getenv(envCfgFile)
 	}
 
-	cfgName = defaultProfile
+	if cfgFile != "" {
+		opts.Viper.SetConfigFile(cfgFile)
+	} else {
+		cfgName := opts.profile
+		if env := os.Getenv(envProfile); env != "" && (cfgName == "" || cfgName == defaultProfile) {
+			cfgName = env
+		}
 
-	if dir := os.Getenv(envCfgHome); dir != "" {
-		dir, err = os.UserConfigDir()
-		cobra.CheckErr(err)
+		if cfgName == "" {
+			cfgName = defaultProfile
+		}
 
-		cfgDir = dir
-	} else {
-		if os.Getenv(envCfgHome) != "" {
-			dir := os.Getenv(envCfgHome)
-			if dir == "" {
-				dir, err = os.UserConfigDir()
-				cobra.CheckErr(err)
-			}
+		cfgDir, err := profilesDir()
+		cobra.CheckErr(err)
 
-			cfgDir = filepath.Join(dir, cmdName)
+		opts.Viper.AddConfigPath(cfgDir)
+		opts.Viper.AddConfigPath(systemCfgDir())
+		opts.Viper.SetConfigName(cfgName)
+	}
 
-			if env := os.Getenv(envProfile); env != "" {
-				cfgName = env
-			}
+	if err := opts.Viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			opts.log.Warn("could not read configuration file", "error", err)
 		}
+
+		return
 	}
 
-	if cfgFile != "" {
-		viper.SetConfigFile(cfgFile)
+	opts.log.Debug("using configuration file", "path", opts.Viper.ConfigFileUsed())
+}
+
+// initLog sets the log level and format from --log-level and --log-format,
+// or OPENSDK_LOG_LEVEL and OPENSDK_LOG_FORMAT when the flags are left at
+// their defaults
+func initLog(opts *Opts) {
+	level, format := opts.logLevel, opts.logFormat
+
+	if env := os.Getenv(convertFlagToEnv(optLogLevel)); env != "" && level == defaultLogLevel {
+		level = env
 	}
 
-	if cfgDir != "" && cfgName != "" {
-		viper.AddConfigPath(cfgDir)
-		viper.SetConfigName(cfgName)
+	if env := os.Getenv(convertFlagToEnv(optLogFormat)); env != "" && format == defaultLogFormat {
+		format = env
 	}
 
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			fmt.Println("Found error: ", err.Error())
+	l, err := logging.ParseLevel(level)
+	if err != nil {
+		opts.log.Warn("ignoring log level", "error", err)
+	}
+
+	f, err := logging.ParseFormat(format)
+	if err != nil {
+		opts.log.Warn("ignoring log format", "error", err)
+	}
+
+	opts.log.SetLevel(l)
+	opts.log.SetFormat(f)
+}
+
+// initLogFile tees the logs to the file named by the log-file setting.
+// Relative names are resolved against the state directory.
+func initLogFile(opts *Opts) {
+	name := opts.Viper.GetString(cfgLogFile)
+	if name == "" {
+		opts.log.SetFile(nil)
+		return
+	}
+
+	if !filepath.IsAbs(name) {
+		dir, err := stateDir()
+		if err != nil {
+			opts.log.Warn("could not open log file", "error", err)
+			return
 		}
+
+		name = filepath.Join(dir, name)
+	}
+
+	file, err := logging.OpenRotating(name, logFileMaxSize, logFileBackups)
+	if err != nil {
+		opts.log.Warn("could not open log file", "error", err)
+		return
 	}
+
+	opts.log.SetFile(file)
 }
 
 // Cmd
@@ -188,8 +421,12 @@ func initCmd(cmd *cobra.Command, opts ...cmdOption) *Cmd {
 	}
 }
 
-// viperBindFlags
-func viperBindFlags() {
+// newViper returns a configuration bound to the OPENSDK_* environment
+// variables
+func newViper() *viper.Viper {
+	v := viper.New()
+	v.SetEnvPrefix(envPrefix)
+
 	for _, env := range os.Environ() {
 		envParts := strings.Split(env, "=")
 		if len(envParts) != 2 {
@@ -205,8 +442,10 @@ func viperBindFlags() {
 			continue
 		}
 
-		_ = viper.BindEnv(flag, envParts[0])
+		_ = v.BindEnv(flag, envParts[0])
 	}
+
+	return v
 }
 
 // convertFlagToEnv
@@ -239,17 +478,46 @@ func cmdPrint(cmd *cobra.Command, r io.Reader) error {
 	return nil
 }
 
-// flagContains
-func flagContains(flag string, values []string) error {
-	flagValue := viper.GetString(flag)
+// printError writes err to stderr, as a JSON object when JSON output was
+// requested or in silent success mode and as plain text otherwise
+func printError(cmd *cobra.Command, opts *Opts, err error) {
+	if silentSuccess(cmd, opts) {
+		printSilentError(cmd, err)
+		return
+	}
 
-	for _, value := range values {
-		if flagValue == value {
-			return nil
+	output := opts.Viper.GetString(optOutput)
+	if flag := cmd.Flags().Lookup(optOutput); flag != nil && flag.Changed {
+		output = flag.Value.String()
+	}
+
+	if output != outputJSON {
+		cmd.PrintErrln(colorize(cmd.ErrOrStderr(), colorRed, i18n.T("Error:")), err.Error())
+
+		if hint := errorHint(err); hint != "" {
+			cmd.PrintErrln(colorize(cmd.ErrOrStderr(), colorYellow, i18n.T("Hint:")), hint)
 		}
+
+		if ExitCode(err) == exitUsage {
+			cmd.PrintErr(i18n.T("Run '%v --help' for usage.\n", cmd.CommandPath()))
+		}
+
+		return
 	}
 
-	return fmt.Errorf(`flag "%s" has invalid value "%s"`, flag, flagValue)
+	encodeError(cmd, err, "  ")
+}
+
+// encodeError writes err to stderr as a JSON object indented with indent.
+// HTML is not escaped, so placeholders such as <id> stay readable.
+func encodeError(cmd *cobra.Command, err error, indent string) {
+	enc := json.NewEncoder(cmd.ErrOrStderr())
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+
+	if jsonErr := enc.Encode(newErrorOutput(err)); jsonErr != nil {
+		cmd.PrintErrln("Error:", err.Error())
+	}
 }
 
 // cmdPreRun
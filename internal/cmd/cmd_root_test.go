@@ -0,0 +1,55 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitGlobalFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  string
+		flags string
+		rest  string
+	}{
+		{"command only", "foo --output json", "", "foo --output json"},
+		{"global flags", "--profile prod --sandbox foo --output json", "--profile prod --sandbox", "foo --output json"},
+		{"equals and shorthand", "--profile=prod -c config.yaml foo", "--profile=prod -c config.yaml", "foo"},
+		{"no command", "--profile prod", "--profile prod", ""},
+		{"missing value", "--profile", "--profile", ""},
+	}
+
+	root, _ := newTestTree(t, "", io.Discard, io.Discard)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags, rest := splitGlobalFlags(root, strings.Fields(tt.args))
+
+			if want := strings.Fields(tt.flags); !reflect.DeepEqual(flags, want) && len(flags)+len(want) > 0 {
+				t.Errorf("got flags %q, want %q", flags, want)
+			}
+
+			if want := strings.Fields(tt.rest); !reflect.DeepEqual(rest, want) && len(rest)+len(want) > 0 {
+				t.Errorf("got rest %q, want %q", rest, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/kballard/go-shellquote"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	shellExit    = "exit"
+	shellHistory = "history"
+	shellQuit    = "quit"
+	historyFile  = "shell_history"
+)
+
+// cmdShell
+func cmdShell(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "shell",
+		Short: "Start an interactive shell",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := runShell(cmd, opts); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// runShell
+func runShell(cmd *cobra.Command, opts *Opts) error {
+	history := ""
+	if dir, err := stateDir(); err == nil {
+		history = filepath.Join(dir, historyFile)
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          cmdName + "> ",
+		HistoryFile:     history,
+		AutoComplete:    shellCompleter{opts: opts},
+		InterruptPrompt: "^C",
+		EOFPrompt:       shellExit,
+		Stdin:           io.NopCloser(opts.Stdin),
+		Stdout:          opts.Stdout,
+		Stderr:          opts.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+	defer rl.Close()
+
+	var globals []string
+
+	// InheritedFlags returns a new set, on which Visit sees nothing
+	cmd.InheritedFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed {
+			globals = append(globals, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+		}
+	})
+
+	tree := opts.fork()
+	root := cmdRoot(tree)
+	loadAllCmds(tree, root.Command)
+
+	var lines []string
+
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			continue
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		args, err := shellquote.Split(line)
+		if err != nil {
+			cmd.PrintErrln("Error:", err)
+			continue
+		}
+
+		if args[0] == cmdName {
+			args = args[1:]
+		}
+
+		if len(args) == 0 {
+			continue
+		}
+
+		switch args[0] {
+		case shellExit, shellQuit:
+			return nil
+		case shellHistory:
+			for i, l := range lines {
+				cmd.Printf("%5d  %s\n", i+1, l)
+			}
+
+			continue
+		case "shell":
+			cmd.PrintErrln("Error: already in a shell")
+			continue
+		}
+
+		lines = append(lines, line)
+
+		// Errors are already reported; the shell keeps going.
+		_ = runShellLine(root, tree, append(append([]string{}, globals...), args...))
+	}
+}
+
+// runShellLine runs a single line on the command tree of the shell, which
+// keeps the configuration loaded by the first line. Aliases and extensions
+// are resolved as on the command line; what execRoot does once the process
+// is done, such as the history and the telemetry, is left to the shell.
+func runShellLine(root *Cmd, opts *Opts, args []string) error {
+	ctx, stop := signalContext()
+	defer stop()
+
+	resetCmds(root.Command, ctx)
+
+	args, err := expandAlias(root, opts, args)
+	if err != nil {
+		err = wrapError(exitFailure, err)
+		printError(root.Command, opts, err)
+
+		return err
+	}
+
+	if ok, err := runExtension(root, opts, args); ok {
+		return err
+	}
+
+	root.SetArgs(args)
+	opts.startStats()
+
+	c, err := root.ExecuteContextC(ctx)
+	if err != nil && ctx.Err() != nil {
+		err = wrapError(exitInterrupted, err)
+	}
+
+	if err != nil {
+		printError(c, opts, err)
+	}
+
+	printStats(opts)
+	saveRequestTrace(opts)
+	saveRateLimit(opts)
+
+	return err
+}
+
+// resetCmds sets the flags of every command back to their defaults, so that
+// a line does not see the flags given on the previous ones, and the context
+// of every command to ctx, which cobra otherwise keeps from the first line
+func resetCmds(root *cobra.Command, ctx context.Context) {
+	walkCmds(root, func(c *cobra.Command) {
+		c.SetContext(ctx)
+
+		for _, flags := range []*pflag.FlagSet{c.PersistentFlags(), c.Flags()} {
+			flags.VisitAll(func(f *pflag.Flag) {
+				if s, ok := f.Value.(pflag.SliceValue); ok {
+					_ = s.Replace(splitFlagDefault(f.DefValue))
+				} else {
+					_ = f.Value.Set(f.DefValue)
+				}
+
+				f.Changed = false
+			})
+		}
+	})
+}
+
+// splitFlagDefault returns the values of the default of a slice flag,
+// written as [a,b]
+func splitFlagDefault(def string) []string {
+	def = strings.TrimSuffix(strings.TrimPrefix(def, "["), "]")
+	if def == "" {
+		return nil
+	}
+
+	return strings.Split(def, ",")
+}
+
+// shellCompleter completes shell input using cobra's completion machinery
+type shellCompleter struct {
+	opts *Opts
+}
+
+// Do
+func (s shellCompleter) Do(line []rune, pos int) ([][]rune, int) {
+	input := string(line[:pos])
+
+	args := strings.Fields(input)
+
+	toComplete := ""
+	if len(args) > 0 && !strings.HasSuffix(input, " ") {
+		toComplete = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+
+	out := new(bytes.Buffer)
+
+	opts := s.opts.fork()
+	opts.Stdout, opts.Stderr = out, io.Discard
+
+	root := cmdRoot(opts)
+	root.SetArgs(append(append([]string{cobra.ShellCompRequestCmd}, args...), toComplete))
+	loadCmds(opts, root.Command, append(append([]string{cobra.ShellCompRequestCmd}, args...), toComplete))
+
+	if err := root.Execute(); err != nil {
+		return nil, 0
+	}
+
+	var candidates [][]rune
+
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		candidate := scanner.Text()
+		if strings.HasPrefix(candidate, ":") {
+			break
+		}
+
+		candidate = strings.SplitN(candidate, "\t", 2)[0]
+		if !strings.HasPrefix(candidate, toComplete) {
+			continue
+		}
+
+		candidates = append(candidates, []rune(strings.TrimPrefix(candidate, toComplete)+" "))
+	}
+
+	return candidates, len([]rune(toComplete))
+}
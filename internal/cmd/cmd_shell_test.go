@@ -0,0 +1,62 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRunShellLine runs lines one after the other on the tree of a shell
+func TestRunShellLine(t *testing.T) {
+	installTestExtension(t)
+
+	cfg := "account: \"1234\"\naliases:\n  acct: config get account --output yaml\n"
+	globals := []string{"--" + optLogLevel, "debug", "--" + optNoInteractive}
+
+	var stdout, stderr bytes.Buffer
+
+	root, opts := newTestTree(t, writeTestCfg(t, cfg), &stdout, &stderr)
+
+	lines := []struct {
+		args string
+		want string
+	}{
+		{"config get account --output json", `"value": "1234"`},
+		{"config get account", "account  string  1234"},
+		{"acct", "value: \"1234\""},
+		{"hello a", "main a"},
+		{"config get account", "account  string  1234"},
+	}
+
+	for _, line := range lines {
+		stdout.Reset()
+
+		if err := runShellLine(root, opts, append(append([]string{}, globals...), strings.Fields(line.args)...)); err != nil {
+			t.Fatalf("%s: %v\n%s", line.args, err, stderr.String())
+		}
+
+		if !strings.Contains(stdout.String(), line.want) {
+			t.Errorf("%s: output %q does not contain %q", line.args, stdout.String(), line.want)
+		}
+	}
+
+	if n := strings.Count(stderr.String(), "using configuration file"); n != 1 {
+		t.Errorf("configuration read %d times, want once:\n%s", n, stderr.String())
+	}
+}
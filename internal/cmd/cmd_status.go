@@ -0,0 +1,191 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/edsonmichaque/opensdk-cli/internal/cmd/formatter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cfgStatusPage     = "status-page-url"
+	statusTimeout     = 10 * time.Second
+	statusSummaryPath = "/api/v2/summary.json"
+)
+
+// cmdStatus
+func cmdStatus(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Check whether the API is up",
+		Long: heredoc.Doc(`
+			Check that the API answers and how long it takes, and, when
+			status-page-url is set, report the ongoing incidents listed on the
+			provider's status page. Exits with status 6 when the API is down.
+		`),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdPreRun(
+				func() error {
+					return opts.Viper.BindPFlags(cmd.Flags())
+				},
+				func() error {
+					return validateFlags(cmd, opts, flagEnum(optOutput, outputText, outputJSON, outputYAML))
+				},
+			)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status := formatter.Status{
+				API: checkAPI(cmd, opts, resolveBaseURL(opts)),
+			}
+
+			if url := opts.Viper.GetString(cfgStatusPage); url != "" {
+				page := checkStatusPage(cmd, opts, url)
+				status.StatusPage = &page
+			}
+
+			output, err := formatter.Format(
+				status, &formatter.Opts{
+					Output: formatter.Output(opts.Viper.GetString(optOutput)),
+					Query:  opts.Viper.GetString(optQuery),
+				},
+			)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			if err := cmdPrint(cmd, output); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			if !status.API.Up {
+				return newError(exitServer, "the API is down")
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagOutput(outputText),
+		withFlagQuery(),
+		withOpts(opts),
+	)
+}
+
+// checkAPI sends a request to url and times the answer. Anything but a
+// server error counts as up: the health check is not authenticated.
+func checkAPI(cmd *cobra.Command, opts *Opts, url string) formatter.APIStatus {
+	status := formatter.APIStatus{URL: url}
+
+	ctx, cancel := requestContext(cmd.Context(), statusTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	start := time.Now()
+
+	resp, err := opts.httpClient().Do(req)
+	status.LatencyMS = time.Since(start).Milliseconds()
+
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	status.StatusCode = resp.StatusCode
+	status.Up = resp.StatusCode < http.StatusInternalServerError
+
+	return status
+}
+
+// checkStatusPage reads the summary of a Statuspage-compatible status page
+func checkStatusPage(cmd *cobra.Command, opts *Opts, url string) formatter.StatusPage {
+	page := formatter.StatusPage{
+		URL:       url,
+		Incidents: []formatter.Incident{},
+	}
+
+	ctx, cancel := requestContext(cmd.Context(), statusTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+statusSummaryPath, nil)
+	if err != nil {
+		page.Error = err.Error()
+		return page
+	}
+
+	resp, err := opts.externalClient().Do(req)
+	if err != nil {
+		page.Error = err.Error()
+		return page
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		page.Error = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		return page
+	}
+
+	var summary struct {
+		Status struct {
+			Indicator   string `json:"indicator"`
+			Description string `json:"description"`
+		} `json:"status"`
+		Incidents []struct {
+			Name      string `json:"name"`
+			Status    string `json:"status"`
+			Impact    string `json:"impact"`
+			Shortlink string `json:"shortlink"`
+		} `json:"incidents"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		page.Error = err.Error()
+		return page
+	}
+
+	page.Indicator = summary.Status.Indicator
+	page.Description = summary.Status.Description
+
+	for _, incident := range summary.Incidents {
+		page.Incidents = append(page.Incidents, formatter.Incident{
+			Name:   incident.Name,
+			Status: incident.Status,
+			Impact: incident.Impact,
+			URL:    incident.Shortlink,
+		})
+	}
+
+	return page
+}
@@ -0,0 +1,248 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/build"
+	"github.com/edsonmichaque/opensdk-cli/internal/logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	cfgTelemetry             = "telemetry"
+	cfgTelemetryEndpoint     = "telemetry-endpoint"
+	defaultTelemetryEndpoint = "https://telemetry.example.com/v1/events"
+	envDoNotTrack            = "DO_NOT_TRACK"
+	telemetryCmdName         = "telemetry"
+	telemetryTimeout         = 2 * time.Second
+	telemetryWait            = 250 * time.Millisecond
+)
+
+// telemetryEvent is everything that is ever sent: no arguments, flag values,
+// identifiers or machine information beyond the platform
+type telemetryEvent struct {
+	Command    string `json:"command"`
+	DurationMS int64  `json:"duration_ms"`
+	ExitCode   int    `json:"exit_code"`
+	Version    string `json:"version"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+}
+
+// cmdTelemetry
+func cmdTelemetry(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   telemetryCmdName,
+		Short: "Manage anonymous usage telemetry",
+	}
+
+	return initCmd(
+		cmd,
+		withOpts(opts),
+		withCmd(
+			cmdTelemetrySet(opts, "enable", true),
+			cmdTelemetrySet(opts, "disable", false),
+			cmdTelemetryStatus(opts),
+		),
+	)
+}
+
+// cmdTelemetrySet
+func cmdTelemetrySet(opts *Opts, use string, enabled bool) *Cmd {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("%s anonymous usage telemetry", map[bool]string{true: "Enable", false: "Disable"}[enabled]),
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action:  "set",
+				Target:  opts.Viper.ConfigFileUsed(),
+				Changes: map[string]interface{}{cfgTelemetry: enabled},
+			}); ok {
+				return err
+			}
+
+			before := opts.Viper.GetBool(cfgTelemetry)
+
+			if err := updateCfgFile(opts, func(v *viper.Viper) {
+				v.Set(cfgTelemetry, enabled)
+			}); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			recordAudit(cmd, opts, "config/"+cfgTelemetry, before, enabled)
+
+			cmd.Printf("Telemetry %sd\n", use)
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// cmdTelemetryStatus
+func cmdTelemetryStatus(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show whether telemetry is enabled and what it sends",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status := "disabled"
+			if telemetryEnabled(opts) {
+				status = "enabled"
+			}
+
+			cmd.Printf("Telemetry: %s\n", status)
+			cmd.Printf("Endpoint:  %s\n", telemetryEndpoint(opts))
+			cmd.Println("Sent after each command: command name, duration, exit code, version, OS and architecture.")
+			cmd.Println("Arguments, flag values and identifiers are never sent.")
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
+
+// telemetryEnabled is false unless telemetry was explicitly turned on, and
+// DO_NOT_TRACK always turns it off
+func telemetryEnabled(opts *Opts) bool {
+	if v := os.Getenv(envDoNotTrack); v != "" && v != "0" {
+		return false
+	}
+
+	return opts.Viper.GetBool(cfgTelemetry)
+}
+
+// telemetryEndpoint
+func telemetryEndpoint(opts *Opts) string {
+	if endpoint := opts.Viper.GetString(cfgTelemetryEndpoint); endpoint != "" {
+		return endpoint
+	}
+
+	return defaultTelemetryEndpoint
+}
+
+// telemetrySend is a telemetry event being sent in the background
+type telemetrySend struct {
+	done chan struct{}
+	log  *logging.Logger
+}
+
+// wait gives the event up to telemetryWait to be sent, so that telemetry
+// never holds the exit of the command up for long
+func (s *telemetrySend) wait() {
+	if s == nil {
+		return
+	}
+
+	select {
+	case <-s.done:
+	case <-time.After(telemetryWait):
+		s.log.Debug("telemetry not sent in time")
+	}
+}
+
+// sendTelemetry starts reporting the command that ran, if telemetry is
+// still enabled once it finished. The telemetry commands themselves are
+// never reported. Failures are only logged.
+func sendTelemetry(cmd *cobra.Command, opts *Opts, err error, elapsed time.Duration) *telemetrySend {
+	if cmd == nil || isTelemetryCmd(cmd) || !telemetryEnabled(opts) || telemetryDisabledInCfg(opts) {
+		return nil
+	}
+
+	body, jsonErr := json.Marshal(telemetryEvent{
+		Command:    hookKey(cmd),
+		DurationMS: elapsed.Milliseconds(),
+		ExitCode:   ExitCode(err),
+		Version:    build.Version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	})
+	if jsonErr != nil {
+		return nil
+	}
+
+	s := &telemetrySend{done: make(chan struct{}), log: opts.log}
+
+	go func() {
+		defer close(s.done)
+
+		ctx, cancel := requestContext(cmd.Context(), telemetryTimeout)
+		defer cancel()
+
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, telemetryEndpoint(opts), bytes.NewReader(body))
+		if reqErr != nil {
+			return
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, postErr := opts.externalClient().Do(req)
+		if postErr != nil {
+			opts.log.Debug("could not send telemetry", "error", postErr)
+			return
+		}
+
+		resp.Body.Close()
+	}()
+
+	return s
+}
+
+// isTelemetryCmd reports whether cmd is the telemetry command or one of its
+// subcommands
+func isTelemetryCmd(cmd *cobra.Command) bool {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if c.Name() == telemetryCmdName && c.Parent() == cmd.Root() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// telemetryDisabledInCfg reads the configuration file again, so that
+// telemetry turned off by the command that just ran is already respected
+func telemetryDisabledInCfg(opts *Opts) bool {
+	file := opts.Viper.ConfigFileUsed()
+	if file == "" {
+		return false
+	}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+
+	if err := v.ReadInConfig(); err != nil {
+		return false
+	}
+
+	return v.IsSet(cfgTelemetry) && !v.GetBool(cfgTelemetry)
+}
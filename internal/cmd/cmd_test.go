@@ -0,0 +1,129 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestNewRootIsolated runs two command trees side by side, each with its own
+// configuration and streams, and checks that neither sees the logs or the
+// configuration of the other
+func TestNewRootIsolated(t *testing.T) {
+	t.Setenv(envStateHome, t.TempDir())
+	t.Setenv(envCfgHome, t.TempDir())
+
+	roots := []struct {
+		config string
+		args   []string
+		want   string
+	}{
+		{"config.yaml", []string{"config", "get", "account"}, "1234"},
+		{"config_output_table.yaml", []string{"config", "get", "account"}, "5678"},
+	}
+
+	stdouts := make([]bytes.Buffer, len(roots))
+	stderrs := make([]bytes.Buffer, len(roots))
+	errs := make([]error, len(roots))
+
+	var wg sync.WaitGroup
+
+	for i, r := range roots {
+		root, err := NewRoot(
+			WithStdin(strings.NewReader("")),
+			WithStdout(&stdouts[i]),
+			WithStderr(&stderrs[i]),
+			WithWorkDir(t.TempDir()),
+			WithConfigFile(filepath.Join(fixturesDir, r.config)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		root.SetArgs(append(r.args, "--"+optNoInteractive, "--"+optLogLevel, "debug"))
+
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_, errs[i] = root.ExecuteC()
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, r := range roots {
+		if errs[i] != nil {
+			t.Fatalf("%s: %v\n%s", r.config, errs[i], stderrs[i].String())
+		}
+
+		if !strings.Contains(stdouts[i].String(), r.want) {
+			t.Errorf("%s: output %q does not contain %q", r.config, stdouts[i].String(), r.want)
+		}
+
+		stderr := stderrs[i].String()
+
+		for j, other := range roots {
+			if want, contains := i == j, strings.Contains(stderr, filepath.Join(fixturesDir, other.config)); want != contains {
+				t.Errorf("%s: logs of %s: got %v, want %v:\n%s", r.config, other.config, contains, want, stderr)
+			}
+		}
+	}
+}
+
+// newTestTree builds the command tree with the configuration file cfg, writing
+// to stdout and stderr, and state and profiles in temporary directories
+func newTestTree(t *testing.T, cfg string, stdout, stderr io.Writer) (*Cmd, *Opts) {
+	t.Helper()
+
+	t.Setenv(envStateHome, t.TempDir())
+	t.Setenv(envCfgHome, t.TempDir())
+
+	opts := &Opts{
+		Stdin:      strings.NewReader(""),
+		Stdout:     stdout,
+		Stderr:     stderr,
+		WorkDir:    t.TempDir(),
+		ConfigFile: cfg,
+		Viper:      newViper(),
+		runState:   newRunState(),
+	}
+
+	root := cmdRoot(opts)
+	loadAllCmds(opts, root.Command)
+
+	return root, opts
+}
+
+// writeTestCfg writes a configuration file with content and returns its path
+func writeTestCfg(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/build"
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+	"github.com/edsonmichaque/opensdk-cli/internal/update"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cfgSelfUpgrade      = "self-upgrade"
+	optCheck            = "check"
+	upgradeCheckTimeout = 30 * time.Second
+	upgradeTimeout      = 5 * time.Minute
+)
+
+// cmdUpgrade
+func cmdUpgrade(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade to the latest release",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := requestContext(cmd.Context(), upgradeCheckTimeout)
+			defer cancel()
+
+			release, err := update.Latest(ctx, opts.externalClient())
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			newer := update.Newer(build.Version, release.Version())
+
+			if opts.Viper.GetBool(optCheck) {
+				cmd.Printf("Current version: %s\n", build.Version)
+				cmd.Printf("Latest version:  %s\n", release.Version())
+
+				if newer {
+					cmd.Printf("A new release is available: %s\n", release.URL)
+				}
+
+				return nil
+			}
+
+			if !newer {
+				cmd.Printf("Already up to date (%s)\n", build.Version)
+				return nil
+			}
+
+			if opts.Viper.IsSet(cfgSelfUpgrade) && !opts.Viper.GetBool(cfgSelfUpgrade) {
+				return newError(exitFailure, fmt.Sprintf("self-upgrade is disabled by the %q setting; upgrade with your package manager", cfgSelfUpgrade))
+			}
+
+			exe, err := os.Executable()
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			exe, err = filepath.EvalSymlinks(exe)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			if ok, err := dryRun(cmd, opts, dryRunChange{
+				Action:  "upgrade",
+				Target:  exe,
+				Changes: map[string]interface{}{"from": build.Version, "to": release.Version()},
+			}); ok {
+				return err
+			}
+
+			if err := confirmAction(opts, i18n.T("Upgrade %s from %s to %s?", exe, build.Version, release.Version())); err != nil {
+				return err
+			}
+
+			ctx, cancel = requestContext(cmd.Context(), upgradeTimeout)
+			defer cancel()
+
+			if err := release.Install(ctx, opts.externalClient(), exe); err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			recordAudit(cmd, opts, "binary/"+exe, build.Version, release.Version())
+
+			cmd.Printf("Upgraded to %s\n", release.Version())
+
+			return nil
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagCheck(),
+		withOpts(opts),
+	)
+}
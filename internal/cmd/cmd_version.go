@@ -17,42 +17,102 @@
 package cmd
 
 import (
+	"fmt"
 	"runtime"
+	"runtime/debug"
 
-	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/edsonmichaque/opensdk-cli/internal/build"
+	"github.com/edsonmichaque/opensdk-cli/internal/cmd/formatter"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
+// cmdVersion
 func cmdVersion(opts *Opts) *Cmd {
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Check version",
 		Args:  cobra.NoArgs,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return viper.BindPFlags(cmd.Flags())
+			return cmdPreRun(
+				func() error {
+					return opts.Viper.BindPFlags(cmd.Flags())
+				},
+				func() error {
+					return validateFlags(cmd, opts, flagEnum(optOutput, outputJSON, outputYAML, outputText))
+				},
+			)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			tpl := heredoc.Doc(`
-				Template CLI version:  %v
-				Template API endpoint: %v
-				Template API version:  %v
-				OS/Arch:               %v/%v
-			`)
-
-			cmd.Printf(
-				tpl,
-				build.Version,
-				"https://example.com",
-				"v1",
-				runtime.GOOS,
-				runtime.GOARCH,
+			output, err := formatter.Format(
+				versionInfo(opts), &formatter.Opts{
+					Output: formatter.Output(opts.Viper.GetString(optOutput)),
+					Query:  opts.Viper.GetString(optQuery),
+				},
 			)
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			if err := cmdPrint(cmd, output); err != nil {
+				return wrapError(exitFailure, err)
+			}
 
 			return nil
 		},
 	}
 
-	return initCmd(cmd, withOpts(opts))
+	return initCmd(
+		cmd,
+		withFlagOutput(outputText),
+		withFlagQuery(),
+		withOpts(opts),
+	)
+}
+
+// versionInfo collects the build metadata and the resolved API settings
+func versionInfo(opts *Opts) formatter.Version {
+	info := formatter.Version{
+		Version:     build.Version,
+		Commit:      build.Commit,
+		Date:        build.Date,
+		GoVersion:   runtime.Version(),
+		Platform:    fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		Environment: resolveEnv(opts),
+		BaseURL:     resolveBaseURL(opts),
+		APIVersion:  "v1",
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch {
+			case s.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = s.Value
+			case s.Key == "vcs.time" && info.Date == "":
+				info.Date = s.Value
+			}
+		}
+	}
+
+	return info
+}
+
+// resolveEnv returns the environment the API calls are sent to
+func resolveEnv(opts *Opts) string {
+	switch {
+	case opts.Viper.GetString(optBaseURL) != "":
+		return envDev
+	case opts.Viper.GetBool(optSandbox):
+		return envSandbox
+	default:
+		return envProd
+	}
+}
+
+// resolveBaseURL
+func resolveBaseURL(opts *Opts) string {
+	if url := opts.Viper.GetString(optBaseURL); url != "" {
+		return url
+	}
+
+	return defaultBaseURL
 }
@@ -0,0 +1,254 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultWebhookPort    = 8080
+	optForwardTo          = "forward-to"
+	optPort               = "port"
+	outputNDJSON          = "ndjson"
+	webhookForwardTimeout = 10 * time.Second
+	webhookMaxBody        = 1 << 20
+)
+
+// webhookEvent is a request received by the listener. Body holds the
+// decoded JSON payload, or the raw payload when it is not JSON.
+type webhookEvent struct {
+	Time    time.Time         `json:"time"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers"`
+	Body    interface{}       `json:"body,omitempty"`
+}
+
+// cmdWebhooks
+func cmdWebhooks(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:     "webhooks",
+		Aliases: []string{"webhook"},
+		Short:   "Develop against webhook events",
+	}
+
+	return initCmd(
+		cmd,
+		withOpts(opts),
+		withCmd(
+			cmdWebhooksListen(opts),
+		),
+	)
+}
+
+// cmdWebhooksListen
+func cmdWebhooksListen(opts *Opts) *Cmd {
+	cmd := &cobra.Command{
+		Use:   "listen",
+		Short: "Receive webhook events on a local port",
+		Long: heredoc.Doc(`
+			Listen on localhost for webhook events and print each one as it
+			arrives. Point a tunnel at the port to receive events from the API,
+			and use --forward-to to pass them on to the consumer you are
+			developing. The listener runs until interrupted.
+		`),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return cmdPreRun(
+				func() error {
+					return opts.Viper.BindPFlags(cmd.Flags())
+				},
+				func() error {
+					return validateFlags(
+						cmd, opts,
+						flagEnum(optOutput, outputText, outputJSON, outputNDJSON),
+						flagRange(optPort, 1, 65535),
+						flagURL(optForwardTo),
+					)
+				},
+			)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ln, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(opts.Viper.GetInt(optPort))))
+			if err != nil {
+				return wrapError(exitFailure, err)
+			}
+
+			srv := &http.Server{
+				Handler:           webhookHandler(cmd, opts, opts.Viper.GetString(optForwardTo), opts.Viper.GetString(optOutput)),
+				ReadHeaderTimeout: webhookForwardTimeout,
+			}
+
+			go func() {
+				<-cmd.Context().Done()
+				_ = srv.Close()
+			}()
+
+			cmd.PrintErrf("Listening for webhook events on http://%s\n", ln.Addr())
+
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return wrapError(exitFailure, err)
+			}
+
+			return nil
+		},
+	}
+
+	return initCmd(
+		cmd,
+		withFlagOutput(outputText),
+		withFlagPort(defaultWebhookPort),
+		withFlagForwardTo(),
+		withOpts(opts),
+	)
+}
+
+// webhookHandler prints the events it receives and forwards them to
+// forwardTo, if set, answering with the status of the forwarded request
+func webhookHandler(cmd *cobra.Command, opts *Opts, forwardTo, output string) http.Handler {
+	var mu sync.Mutex
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, webhookMaxBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		event := newWebhookEvent(r, body)
+
+		status := http.StatusOK
+		if forwardTo != "" {
+			status = forwardWebhook(opts, r, body, forwardTo)
+		}
+
+		mu.Lock()
+		printWebhookEvent(cmd, event, output, status, forwardTo != "")
+		mu.Unlock()
+
+		w.WriteHeader(status)
+	})
+}
+
+// newWebhookEvent
+func newWebhookEvent(r *http.Request, body []byte) webhookEvent {
+	headers := make(map[string]string, len(r.Header))
+	for key := range r.Header {
+		value := r.Header.Get(key)
+		if isSensitive(key) {
+			value = redacted
+		}
+
+		headers[key] = value
+	}
+
+	event := webhookEvent{
+		Time:    time.Now().UTC(),
+		Method:  r.Method,
+		Path:    r.URL.RequestURI(),
+		Headers: headers,
+	}
+
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &event.Body); err != nil {
+			event.Body = string(body)
+		}
+	}
+
+	return event
+}
+
+// forwardWebhook replays the request against url and returns the status it
+// was answered with, or 502 Bad Gateway when it could not be delivered
+func forwardWebhook(opts *Opts, r *http.Request, body []byte, url string) int {
+	ctx, cancel := requestContext(r.Context(), webhookForwardTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, url, bytes.NewReader(body))
+	if err != nil {
+		opts.log.Warn("could not forward webhook event", "error", err)
+		return http.StatusBadGateway
+	}
+
+	for key, values := range r.Header {
+		if strings.EqualFold(key, "Host") || strings.EqualFold(key, "Content-Length") {
+			continue
+		}
+
+		req.Header[key] = values
+	}
+
+	resp, err := opts.externalClient().Do(req)
+	if err != nil {
+		opts.log.Warn("could not forward webhook event", "url", url, "error", err)
+		return http.StatusBadGateway
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode
+}
+
+// printWebhookEvent writes event to the standard output, one JSON object per
+// line for ndjson and pretty-printed otherwise
+func printWebhookEvent(cmd *cobra.Command, event webhookEvent, output string, status int, forwarded bool) {
+	switch output {
+	case outputNDJSON:
+		data, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+
+		cmd.Println(string(data))
+	case outputJSON:
+		data, err := json.MarshalIndent(event, "", "  ")
+		if err != nil {
+			return
+		}
+
+		cmd.Println(string(data))
+	default:
+		cmd.Printf("%s  %s %s\n", event.Time.Local().Format(time.RFC3339), event.Method, event.Path)
+
+		if event.Body != nil {
+			data, err := json.MarshalIndent(event.Body, "", "  ")
+			if err == nil {
+				cmd.Println(string(data))
+			}
+		}
+
+		if forwarded {
+			cmd.Printf("-> %d %s\n", status, http.StatusText(status))
+		}
+
+		cmd.Println()
+	}
+}
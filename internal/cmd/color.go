@@ -0,0 +1,73 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+const (
+	envNoColor       = "NO_COLOR"
+	envCliColor      = "CLICOLOR"
+	envCliColorForce = "CLICOLOR_FORCE"
+	envTerm          = "TERM"
+
+	colorRed    = 31
+	colorYellow = 33
+)
+
+// colorEnabled follows the NO_COLOR and CLICOLOR conventions: NO_COLOR
+// always wins, CLICOLOR_FORCE turns colors on even when w is not a
+// terminal, and CLICOLOR=0 or TERM=dumb turn them off
+func colorEnabled(w interface{}) bool {
+	switch {
+	case os.Getenv(envNoColor) != "":
+		return false
+	case os.Getenv(envCliColorForce) != "" && os.Getenv(envCliColorForce) != "0":
+		return true
+	case os.Getenv(envCliColor) == "0", os.Getenv(envTerm) == "dumb":
+		return false
+	default:
+		return isTerminal(w)
+	}
+}
+
+// colorMu guards the prompt colors, a setting of the whole process that
+// every command tree sets
+var colorMu sync.Mutex
+
+// initColor turns off the colors of the prompts when the standard error of
+// opts should not get any
+func initColor(opts *Opts) {
+	colorMu.Lock()
+	defer colorMu.Unlock()
+
+	core.DisableColor = !colorEnabled(opts.Stderr)
+}
+
+// colorize wraps s in the ANSI escape for color when w accepts colors
+func colorize(w interface{}, color int, s string) string {
+	if !colorEnabled(w) {
+		return s
+	}
+
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", color, s)
+}
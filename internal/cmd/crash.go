@@ -0,0 +1,142 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/build"
+	"github.com/getsentry/sentry-go"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cfgCrashReportDSN  = "crash-report-dsn"
+	crashReportTimeout = 2 * time.Second
+)
+
+// initCrashReporting sets up the Sentry client when crash-report-dsn is
+// set. Events only carry the command path, the stack trace and the
+// platform: arguments, the host name and secrets are stripped.
+func initCrashReporting(opts *Opts) bool {
+	dsn := opts.Viper.GetString(cfgCrashReportDSN)
+	if dsn == "" {
+		return false
+	}
+
+	if err := sentry.Init(sentry.ClientOptions{
+		Dsn:              dsn,
+		Release:          build.Version,
+		Environment:      resolveEnv(opts),
+		AttachStacktrace: true,
+		BeforeSend: func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+			return scrubEvent(event, secretReplacer(opts))
+		},
+	}); err != nil {
+		opts.log.Warn("could not set up crash reporting", "error", err)
+		return false
+	}
+
+	return true
+}
+
+// reportPanic reports a panic of the command, then resumes panicking
+func reportPanic(opts *Opts) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	if initCrashReporting(opts) {
+		sentry.CurrentHub().Recover(r)
+		sentry.Flush(crashReportTimeout)
+	}
+
+	panic(r)
+}
+
+// reportError reports unexpected failures. Errors with a specific exit code
+// (usage, auth, not found...) are expected and left out.
+func reportError(cmd *cobra.Command, opts *Opts, err error) {
+	if err == nil || ExitCode(err) != exitFailure || !initCrashReporting(opts) {
+		return
+	}
+
+	sentry.WithScope(func(scope *sentry.Scope) {
+		if cmd != nil {
+			scope.SetTag("command", hookKey(cmd))
+		}
+
+		sentry.CaptureException(err)
+	})
+
+	sentry.Flush(crashReportTimeout)
+}
+
+// scrubEvent removes everything that could identify the user from event
+// and hides the secrets with replacer
+func scrubEvent(event *sentry.Event, replacer *strings.Replacer) *sentry.Event {
+	event.ServerName = ""
+	event.User = sentry.User{}
+	event.Request = nil
+	event.Extra = nil
+	event.Breadcrumbs = nil
+
+	event.Message = replacer.Replace(event.Message)
+
+	for i := range event.Exception {
+		event.Exception[i].Value = replacer.Replace(event.Exception[i].Value)
+
+		if st := event.Exception[i].Stacktrace; st != nil {
+			for j := range st.Frames {
+				st.Frames[j].Vars = nil
+			}
+		}
+	}
+
+	return event
+}
+
+// secretReplacer replaces the values of sensitive settings, the command
+// line arguments and the home directory with placeholders
+func secretReplacer(opts *Opts) *strings.Replacer {
+	var pairs []string
+
+	for _, key := range opts.Viper.AllKeys() {
+		if !isSensitive(key) {
+			continue
+		}
+
+		if value := opts.Viper.GetString(key); value != "" {
+			pairs = append(pairs, value, redacted)
+		}
+	}
+
+	for _, arg := range os.Args[1:] {
+		if len(arg) > 3 {
+			pairs = append(pairs, arg, redacted)
+		}
+	}
+
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		pairs = append(pairs, home, "~")
+	}
+
+	return strings.NewReplacer(pairs...)
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	redacted = "[redacted]"
+)
+
+// dryRunChange describes a change a mutating command would perform
+type dryRunChange struct {
+	Action  string                 `json:"action"`
+	Target  string                 `json:"target"`
+	Changes map[string]interface{} `json:"changes,omitempty"`
+}
+
+// dryRun prints change and reports true when --dry-run is set, in which
+// case the caller must return without side effects
+func dryRun(cmd *cobra.Command, opts *Opts, change dryRunChange) (bool, error) {
+	if !opts.Viper.GetBool(optDryRun) {
+		return false, nil
+	}
+
+	data, err := json.MarshalIndent(change, "", "  ")
+	if err != nil {
+		return true, wrapError(exitFailure, err)
+	}
+
+	cmd.Println(string(data))
+
+	return true, nil
+}
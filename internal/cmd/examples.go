@@ -0,0 +1,346 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+)
+
+// cmdExamples holds the Example section of every command, keyed by command
+// path without the binary name
+var cmdExamples = map[string]string{
+	cmdName: heredoc.Doc(`
+		opensdk init
+		opensdk foo --output json
+		opensdk examples
+	`),
+	"alias": heredoc.Doc(`
+		opensdk alias set prodfoo 'foo --profile prod --output json'
+		opensdk alias list
+	`),
+	"alias delete": heredoc.Doc(`
+		opensdk alias delete prodfoo
+		opensdk alias delete prodfoo --force
+	`),
+	"alias list": heredoc.Doc(`
+		opensdk alias list
+	`),
+	"alias set": heredoc.Doc(`
+		opensdk alias set prodfoo 'foo --profile prod --output json'
+		opensdk alias set q 'foo --output json --query $1'
+	`),
+	"audit": heredoc.Doc(`
+		opensdk audit list
+		opensdk audit list --since 7d --output json
+	`),
+	"audit list": heredoc.Doc(`
+		opensdk audit list
+		opensdk audit list --since 7d
+		opensdk audit list --since 2023-01-01 --output json
+		opensdk audit list --filter='command == "config set"'
+	`),
+	"bar": heredoc.Doc(`
+		opensdk bar
+		opensdk bar --output=json
+		opensdk bar --output=yaml
+		opensdk bar --output=json --query="[].id"
+	`),
+	"completion": heredoc.Doc(`
+		source <(opensdk completion bash)
+		opensdk completion zsh > "${fpath[1]}/_opensdk"
+		opensdk completion fish > ~/.config/fish/completions/opensdk.fish
+		opensdk completion powershell | Out-String | Invoke-Expression
+	`),
+	"config": heredoc.Doc(`
+		opensdk config get account
+		opensdk config set account 1234
+	`),
+	"config get": heredoc.Doc(`
+		opensdk config get account
+		opensdk config get base-url --output json
+		opensdk config get access-token --copy
+	`),
+	"config init": heredoc.Doc(`
+		opensdk config init
+		opensdk config init --profile staging
+		opensdk config init --from-file profile.yaml
+		cat profile.json | opensdk config init --profile ci --force
+	`),
+	"config set": heredoc.Doc(`
+		opensdk config set account 1234
+		opensdk config set sandbox true --profile staging
+		opensdk config set
+	`),
+	"debug": heredoc.Doc(`
+		opensdk debug bundle
+	`),
+	"debug bundle": heredoc.Doc(`
+		opensdk debug bundle
+		opensdk debug bundle --dir /tmp
+	`),
+	"docs": heredoc.Doc(`
+		opensdk docs man --dir ./out
+	`),
+	"docs man": heredoc.Doc(`
+		opensdk docs man --dir ./out
+	`),
+	"docs markdown": heredoc.Doc(`
+		opensdk docs markdown --dir ./out
+	`),
+	"examples": heredoc.Doc(`
+		opensdk examples
+		opensdk examples ci
+	`),
+	"extension": heredoc.Doc(`
+		opensdk extension list
+		opensdk extension install octocat/opensdk-hello
+	`),
+	"extension install": heredoc.Doc(`
+		opensdk extension install octocat/opensdk-hello
+	`),
+	"extension list": heredoc.Doc(`
+		opensdk extension list
+	`),
+	"extension remove": heredoc.Doc(`
+		opensdk extension remove hello
+	`),
+	"favorite": heredoc.Doc(`
+		opensdk favorite add 12345 --name acme
+		opensdk favorites list
+		opensdk foo --account @acme
+	`),
+	"favorite add": heredoc.Doc(`
+		opensdk favorite add example.com
+		opensdk favorite add 12345 --name acme
+		opensdk foo --account @acme
+	`),
+	"favorite list": heredoc.Doc(`
+		opensdk favorites list
+	`),
+	"favorite remove": heredoc.Doc(`
+		opensdk favorite remove acme
+	`),
+	"foo": heredoc.Doc(`
+		opensdk foo
+		opensdk foo --output=json
+		opensdk foo --output=yaml
+		opensdk foo --output=json --query="[].id"
+		opensdk foo --filter='name == "www" && id < 10'
+		opensdk foo --filter='name == "www"' --fail-on-empty
+		opensdk foo --profiles prod,staging
+		opensdk foo --all-profiles --output json
+	`),
+	"history": heredoc.Doc(`
+		opensdk history
+		opensdk history --output json
+		opensdk history --filter='exit_code != 0'
+	`),
+	"history export": heredoc.Doc(`
+		opensdk history export > history.json
+		opensdk history export --output yaml
+	`),
+	"history replay": heredoc.Doc(`
+		opensdk history replay 12
+	`),
+	"init": heredoc.Doc(`
+		opensdk init
+		opensdk init --profile staging
+	`),
+	"ratelimit": heredoc.Doc(`
+		opensdk ratelimit status
+	`),
+	"ratelimit status": heredoc.Doc(`
+		opensdk ratelimit status
+		opensdk ratelimit status --output json --query remaining
+	`),
+	"shell": heredoc.Doc(`
+		opensdk shell
+		opensdk shell --profile prod
+	`),
+	"status": heredoc.Doc(`
+		opensdk status
+		opensdk status --output json
+		opensdk config set status-page-url https://status.example.com
+	`),
+	"telemetry": heredoc.Doc(`
+		opensdk telemetry status
+		opensdk telemetry disable
+	`),
+	"telemetry status": heredoc.Doc(`
+		opensdk telemetry status
+	`),
+	"telemetry enable": heredoc.Doc(`
+		opensdk telemetry enable
+		opensdk telemetry enable --dry-run
+	`),
+	"telemetry disable": heredoc.Doc(`
+		opensdk telemetry disable
+	`),
+	"upgrade": heredoc.Doc(`
+		opensdk upgrade --check
+		opensdk upgrade
+		opensdk upgrade --force
+	`),
+	"version": heredoc.Doc(`
+		opensdk version
+		opensdk version --output json
+	`),
+	"webhooks": heredoc.Doc(`
+		opensdk webhooks listen
+		opensdk webhooks listen --forward-to http://localhost:3000/webhooks
+	`),
+	"webhooks listen": heredoc.Doc(`
+		opensdk webhooks listen
+		opensdk webhooks listen --port 9000 --output ndjson
+		opensdk webhooks listen --forward-to http://localhost:3000/webhooks
+	`),
+}
+
+// exampleTopics holds the recipes printed by the examples command
+var exampleTopics = map[string]string{
+	"aliases": heredoc.Doc(`
+		# Save a long invocation under a short name
+		opensdk alias set prodfoo 'foo --profile prod --output json'
+		opensdk prodfoo
+
+		# Use $1, $2, ... for positional arguments
+		opensdk alias set q 'foo --output json --query $1'
+		opensdk q '[].name'
+	`),
+	"ci": heredoc.Doc(`
+		# Pass credentials through the environment, never prompt
+		export OPENSDK_ACCOUNT=1234
+		export OPENSDK_ACCESS_TOKEN="$TOKEN"
+		opensdk foo --no-interactive --output json
+
+		# Branch on the exit code (see docs/exit-codes.md)
+		opensdk foo --no-interactive
+		case $? in
+		  0) ;;
+		  4) echo "not found" ;;
+		  *) exit 1 ;;
+		esac
+
+		# Preview changes before making them
+		opensdk config set account 1234 --dry-run
+
+		# GitHub Actions: errors become annotations, list commands add a
+		# job summary and exit_code, request_id and items are step outputs
+		opensdk foo --no-interactive
+
+		# Record the API traffic of a script once, then replay it offline
+		OPENSDK_VCR=record OPENSDK_VCR_CASSETTE=testdata/foo.json ./script.sh
+		OPENSDK_VCR=replay OPENSDK_VCR_CASSETTE=testdata/foo.json ./script.sh
+
+		# Cron jobs: no output unless the command fails
+		opensdk foo --silent-success
+
+		# Export a trace of each run to an OTLP/HTTP collector
+		export OTEL_EXPORTER_OTLP_ENDPOINT=http://otel-collector:4318
+
+		# Push per-run metrics of scheduled jobs to a Prometheus Pushgateway
+		opensdk config set pushgateway-url http://pushgateway:9091
+
+		# Keep a rotating log of every run under the state directory
+		opensdk config set log-file opensdk.log
+
+		# Managed installations: no new-version notice, no self-upgrade
+		export OPENSDK_UPDATE_NOTICE=false
+		opensdk config set self-upgrade false
+	`),
+	"config": heredoc.Doc(`
+		# Create a profile interactively
+		opensdk init --profile staging
+
+		# Change a single value
+		opensdk config set sandbox true --profile staging
+
+		# Use another configuration file
+		opensdk foo --config-file ./opensdk.yaml
+	`),
+	"hooks": heredoc.Doc(`
+		# In the configuration file, run scripts around a command:
+		#
+		#   hooks:
+		#     config set:
+		#       pre: ./backup.sh
+		#       post: ./notify.sh
+		#
+		# Post hooks get the command output on stdin.
+		opensdk config set account 1234
+	`),
+	"output": heredoc.Doc(`
+		# Pick the output format
+		opensdk foo --output table
+		opensdk foo --output yaml
+
+		# Filter JSON output with a JMESPath query
+		opensdk foo --output json --query '[].id'
+	`),
+}
+
+// withExamples sets the Example section of cmd and all its subcommands
+// from cmdExamples
+func withExamples() cmdOption {
+	return func(cmd *cobra.Command) {
+		walkCmds(cmd, func(c *cobra.Command) {
+			if example, ok := cmdExamples[hookKey(c)]; ok {
+				c.Example = strings.TrimSuffix(example, "\n")
+			}
+		})
+	}
+}
+
+// cmdExamplesTopics
+func cmdExamplesTopics(opts *Opts) *Cmd {
+	topics := make([]string, 0, len(exampleTopics))
+	for topic := range exampleTopics {
+		topics = append(topics, topic)
+	}
+
+	sort.Strings(topics)
+
+	cmd := &cobra.Command{
+		Use:       "examples [topic]",
+		Short:     "Show copy-pasteable recipes",
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: topics,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				cmd.Println("Available topics:")
+
+				for _, topic := range topics {
+					cmd.Printf("  %s\n", topic)
+				}
+
+				cmd.Printf("\nRun '%s examples <topic>' to show a topic.\n", cmdName)
+
+				return nil
+			}
+
+			cmd.Print(exampleTopics[args[0]])
+
+			return nil
+		},
+	}
+
+	return initCmd(cmd, withOpts(opts))
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// TestCmdExamples checks that every command has examples, so that its help
+// and the generated reference show how to use it
+func TestCmdExamples(t *testing.T) {
+	root, _ := newTestTree(t, "", io.Discard, io.Discard)
+
+	walkCmds(root.Command, func(c *cobra.Command) {
+		if c.Hidden || c.Name() == "help" {
+			return
+		}
+
+		if c.Example == "" {
+			t.Errorf("%q has no examples in cmdExamples", hookKey(c))
+		}
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/antonmedv/expr"
+)
+
+const (
+	optFailOnEmpty = "fail-on-empty"
+	optFilter      = "filter"
+)
+
+// filterItems keeps the items matching --filter, an expression such as
+//
+//	type == "A" && ttl < 300
+//
+// evaluated against the JSON fields of each item
+func filterItems[S ~[]E, E any](opts *Opts, items S) (S, error) {
+	filter := opts.Viper.GetString(optFilter)
+	if filter == "" {
+		return items, nil
+	}
+
+	program, err := expr.Compile(filter, expr.AsBool(), expr.AllowUndefinedVariables())
+	if err != nil {
+		return nil, wrapError(exitUsage, fmt.Errorf("invalid filter: %w", err))
+	}
+
+	filtered := make(S, 0, len(items))
+
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, wrapError(exitFailure, err)
+		}
+
+		var env map[string]interface{}
+		if err := json.Unmarshal(data, &env); err != nil {
+			return nil, wrapError(exitFailure, err)
+		}
+
+		ok, err := expr.Run(program, env)
+		if err != nil {
+			return nil, wrapError(exitUsage, fmt.Errorf("invalid filter: %w", err))
+		}
+
+		if ok, _ := ok.(bool); ok {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered, nil
+}
+
+// failOnEmpty returns a not found error for an empty result when
+// --fail-on-empty is set
+func failOnEmpty(opts *Opts, n int) error {
+	if n == 0 && opts.Viper.GetBool(optFailOnEmpty) {
+		return newError(exitNotFound, "no results found")
+	}
+
+	return nil
+}
@@ -18,22 +18,32 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
-func withFlagsGlobal() cmdOption {
+func withFlagsGlobal(opts *Opts) cmdOption {
 	return func(cmd *cobra.Command) {
 		cmd.PersistentFlags().Bool(optSandbox, false, "Sandbox environment")
 		cmd.PersistentFlags().Bool(optNoInteractive, false, "No interactive")
+		cmd.PersistentFlags().Bool(optForce, false, "Skip confirmation of destructive actions")
+		cmd.PersistentFlags().Bool(optDryRun, false, "Print the changes instead of performing them")
+		cmd.PersistentFlags().Bool(optNotify, false, "Send a notification when the command finishes")
+		cmd.PersistentFlags().Bool(optSilentSuccess, false, "Print nothing on success and a one-line JSON error on failure")
+		cmd.PersistentFlags().Bool(optStats, false, "Print API call and timing statistics when the command finishes")
+		cmd.PersistentFlags().Bool(optStrictConfig, false, "Fail on unknown configuration keys and environment variables")
 		cmd.PersistentFlags().String(optAccessToken, "", "Access token")
 		cmd.PersistentFlags().String(optAccount, "", "Account")
 		cmd.PersistentFlags().String(optBaseURL, "", "Base URL")
-		cmd.PersistentFlags().StringVar(&profile, optProfile, defaultProfile, "Profile")
-		cmd.PersistentFlags().StringVarP(&configFile, optConfigFile, "c", "", "Configuration file")
+		cmd.PersistentFlags().StringVar(&opts.profile, optProfile, defaultProfile, "Profile")
+		cmd.PersistentFlags().StringVarP(&opts.ConfigFile, optConfigFile, "c", opts.ConfigFile, "Configuration file")
+		cmd.PersistentFlags().StringVar(&opts.logLevel, optLogLevel, defaultLogLevel, "Log level (debug, info, warn, error)")
+		cmd.PersistentFlags().StringVar(&opts.logFormat, optLogFormat, defaultLogFormat, "Log format (text, json)")
 
 		cmd.MarkFlagsMutuallyExclusive(optBaseURL, optSandbox)
 
-		viper.SetEnvPrefix(envPrefix)
+		_ = cmd.RegisterFlagCompletionFunc(optProfile, completeProfiles)
+		_ = cmd.RegisterFlagCompletionFunc(optConfigFile, completeCfgFiles)
+		_ = cmd.RegisterFlagCompletionFunc(optLogLevel, completeValues("debug", "info", "warn", "error"))
+		_ = cmd.RegisterFlagCompletionFunc(optLogFormat, completeValues("text", "json"))
 	}
 }
 
@@ -41,6 +51,84 @@ func withFlagsGlobal() cmdOption {
 func withFlagOutput(value string) cmdOption {
 	return func(cmd *cobra.Command) {
 		cmd.Flags().StringP(optOutput, "o", value, "Output format")
+
+		_ = cmd.RegisterFlagCompletionFunc(
+			optOutput,
+			completeValues(outputJSON, outputYAML, outputTable, outputText),
+		)
+	}
+}
+
+// withFlagCheck adds check flag to command
+func withFlagCheck() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Bool(optCheck, false, "Only check for a new release")
+	}
+}
+
+// withFlagCopy adds copy flag to command
+func withFlagCopy() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Bool(optCopy, false, "Copy the value to the clipboard")
+	}
+}
+
+// withFlagDir adds dir flag to command
+func withFlagDir() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(optDir, ".", "Output directory")
+	}
+}
+
+// withFlagFailOnEmpty adds fail-on-empty flag to command
+func withFlagFailOnEmpty() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Bool(optFailOnEmpty, false, "Exit with status 4 when there are no results")
+	}
+}
+
+// withFlagFanOut adds the profiles and all-profiles flags to command
+func withFlagFanOut() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().StringSlice(optProfiles, nil, "Run against these profiles and add a profile column")
+		cmd.Flags().Bool(optAllProfiles, false, "Run against every profile and add a profile column")
+
+		_ = cmd.RegisterFlagCompletionFunc(optProfiles, completeProfiles)
+	}
+}
+
+// withFlagFilter adds filter flag to command
+func withFlagFilter() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(optFilter, "", `Only show items matching an expression, e.g. 'name == "www" && id < 10'`)
+	}
+}
+
+// withFlagForwardTo adds forward-to flag to command
+func withFlagForwardTo() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(optForwardTo, "", "Forward the events to this URL")
+	}
+}
+
+// withFlagFromFile adds from-file flag to command
+func withFlagFromFile() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().StringP(optFromFile, "f", "", `Read the values from a JSON or YAML file, or "-" for stdin`)
+	}
+}
+
+// withFlagName adds name flag to command
+func withFlagName() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(optName, "", "Name of the shortcut, by default the value up to the first dot")
+	}
+}
+
+// withFlagPort adds port flag to command
+func withFlagPort(value int) cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Int(optPort, value, "Port to listen on")
 	}
 }
 
@@ -51,6 +139,20 @@ func withFlagQuery() cmdOption {
 	}
 }
 
+// withFlagSince adds since flag to command
+func withFlagSince() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(optSince, "", "Only show entries newer than a duration (7d, 12h) or date (2006-01-02)")
+	}
+}
+
+// withLogger sends the log records to the standard error of opts
+func withLogger(opts *Opts) cmdOption {
+	return func(cmd *cobra.Command) {
+		opts.log.SetOutput(opts.Stderr)
+	}
+}
+
 func withOpts(opts *Opts) cmdOption {
 	return func(cmd *cobra.Command) {
 		cmd.SetOutput(opts.Stdout)
@@ -91,20 +91,14 @@ func (f ConfigList) formatHeader() []string {
 	}
 }
 
-func (f ConfigList) formatRows() []map[string]string {
-	data := make([]map[string]string, 0, len(f))
-
-	fooList := f
-
-	const txtLen = 10
+func (f ConfigList) formatLen() int {
+	return len(f)
+}
 
-	for i := range fooList {
-		data = append(data, map[string]string{
-			"NAME":  fooList[i].Name,
-			"TYPE":  fmt.Sprintf("%v", fooList[i].Type),
-			"VALUE": fooList[i].Value,
-		})
+func (f ConfigList) formatRow(i int) map[string]string {
+	return map[string]string{
+		"NAME":  f[i].Name,
+		"TYPE":  fmt.Sprintf("%v", f[i].Type),
+		"VALUE": f[i].Value,
 	}
-
-	return data
 }
@@ -17,13 +17,13 @@
 package formatter
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"strings"
-	"text/tabwriter"
+	"unicode/utf8"
 
 	"github.com/jmespath/go-jmespath"
 	"gopkg.in/yaml.v3"
@@ -32,10 +32,11 @@ import (
 type Output string
 
 const (
-	OutputText  = Output("text")
-	OutputTable = Output("table")
-	OutputJSON  = Output("json")
-	OutputYAML  = Output("yaml")
+	OutputText     = Output("text")
+	OutputTable    = Output("table")
+	OutputJSON     = Output("json")
+	OutputYAML     = Output("yaml")
+	OutputMarkdown = Output("markdown")
 )
 
 type Opts struct {
@@ -84,6 +85,14 @@ func Format(data interface{}, opts *Opts) (io.Reader, error) {
 		return nil, errors.New("table formatter is not implemented")
 	}
 
+	if opts.Output == OutputMarkdown {
+		if formatter, ok := data.(tableFormatter); ok {
+			return formatMarkdown(formatter)
+		}
+
+		return nil, errors.New("markdown formatter is not implemented")
+	}
+
 	if opts.Output == OutputText {
 		if formatter, ok := data.(TextFormatter); ok {
 			return formatter.FormatText(opts)
@@ -155,36 +164,135 @@ type jsonFormatter interface {
 	formatJSON(opts *Opts) ([]byte, error)
 }
 
+// tableSampleRows is the number of rows used to size the table columns.
+// Longer cells in the rows that follow push the next columns out.
+const tableSampleRows = 100
+
 type tableFormatter interface {
 	formatHeader() []string
-	formatRows() []map[string]string
+	formatLen() int
+	formatRow(i int) map[string]string
 }
 
+// formatTable renders t into memory
 func formatTable(t tableFormatter) (io.Reader, error) {
-	buf := new(bytes.Buffer)
-	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	var buf bytes.Buffer
 
-	if _, err := fmt.Fprintln(tw, strings.Join(t.formatHeader(), "\t")); err != nil {
+	if err := writeTable(&buf, t); err != nil {
 		return nil, err
 	}
 
-	for _, v := range t.formatRows() {
-		row := make([]string, 0)
+	return &buf, nil
+}
 
-		for _, col := range t.formatHeader() {
-			if v, ok := v[col]; ok {
-				row = append(row, v)
+// writeTable writes t to w, sizing the columns from the header and the
+// first tableSampleRows rows
+func writeTable(w io.Writer, t tableFormatter) error {
+	bw := bufio.NewWriter(w)
+	header := t.formatHeader()
+
+	sample := make([][]string, 0, tableSampleRows)
+	for i := 0; i < t.formatLen() && i < tableSampleRows; i++ {
+		sample = append(sample, tableRow(header, t.formatRow(i)))
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, sample...) {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
 			}
 		}
+	}
 
-		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
-			return nil, err
+	if err := writeTableRow(bw, header, widths); err != nil {
+		return err
+	}
+
+	for _, row := range sample {
+		if err := writeTableRow(bw, row, widths); err != nil {
+			return err
 		}
 	}
 
-	if err := tw.Flush(); err != nil {
-		return nil, err
+	for i := len(sample); i < t.formatLen(); i++ {
+		if err := writeTableRow(bw, tableRow(header, t.formatRow(i)), widths); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// tableRow returns the cells of v in header order
+func tableRow(header []string, v map[string]string) []string {
+	row := make([]string, 0, len(header))
+
+	for _, col := range header {
+		if v, ok := v[col]; ok {
+			row = append(row, v)
+		}
+	}
+
+	return row
+}
+
+// writeTableRow writes row padded to widths, with at least two spaces
+// between cells
+func writeTableRow(w *bufio.Writer, row []string, widths []int) error {
+	for i, cell := range row {
+		if _, err := w.WriteString(cell); err != nil {
+			return err
+		}
+
+		if i == len(row)-1 {
+			break
+		}
+
+		pad := 2
+		if i < len(widths) {
+			pad += widths[i] - utf8.RuneCountInString(cell)
+		}
+
+		if pad < 2 {
+			pad = 2
+		}
+
+		if _, err := w.WriteString(strings.Repeat(" ", pad)); err != nil {
+			return err
+		}
+	}
+
+	return w.WriteByte('\n')
+}
+
+// formatMarkdown renders t as a markdown table
+func formatMarkdown(t tableFormatter) (io.Reader, error) {
+	buf := new(bytes.Buffer)
+	header := t.formatHeader()
+
+	writeMarkdownRow(buf, header)
+
+	sep := make([]string, len(header))
+	for i := range sep {
+		sep[i] = "---"
+	}
+
+	writeMarkdownRow(buf, sep)
+
+	for i := 0; i < t.formatLen(); i++ {
+		writeMarkdownRow(buf, tableRow(header, t.formatRow(i)))
 	}
 
 	return buf, nil
 }
+
+// writeMarkdownRow
+func writeMarkdownRow(buf *bytes.Buffer, row []string) {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		cells[i] = strings.ReplaceAll(strings.ReplaceAll(cell, "|", "\\|"), "\n", " ")
+	}
+
+	buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+type AuditEntry struct {
+	Time     time.Time   `json:"time"`
+	User     string      `json:"user"`
+	Host     string      `json:"host"`
+	Command  string      `json:"command"`
+	Resource string      `json:"resource"`
+	Before   interface{} `json:"before"`
+	After    interface{} `json:"after"`
+}
+
+type AuditList []AuditEntry
+
+func (a AuditList) FormatJSON(opts *Opts) (io.Reader, error) {
+	return formatJSON(a, opts)
+}
+
+func (a AuditList) FormatYAML(opts *Opts) (io.Reader, error) {
+	return formatYAML(a, opts)
+}
+
+func (a AuditList) FormatTable(_ *Opts) (io.Reader, error) {
+	return formatTable(a)
+}
+
+func (a AuditList) formatJSON(opts *Opts) ([]byte, error) {
+	return json.MarshalIndent(a, "", "  ")
+}
+
+func (a AuditList) formatHeader() []string {
+	return []string{
+		"TIME",
+		"USER",
+		"HOST",
+		"COMMAND",
+		"RESOURCE",
+		"BEFORE",
+		"AFTER",
+	}
+}
+
+func (a AuditList) formatLen() int {
+	return len(a)
+}
+
+func (a AuditList) formatRow(i int) map[string]string {
+	return map[string]string{
+		"TIME":     a[i].Time.Local().Format(time.RFC3339),
+		"USER":     a[i].User,
+		"HOST":     a[i].Host,
+		"COMMAND":  a[i].Command,
+		"RESOURCE": a[i].Resource,
+		"BEFORE":   auditValue(a[i].Before),
+		"AFTER":    auditValue(a[i].After),
+	}
+}
+
+func auditValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -23,9 +23,10 @@ import (
 )
 
 type Foo struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
-	Age  string `json:"age"`
+	Profile string `json:"profile,omitempty"`
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Age     string `json:"age"`
 }
 
 type FooList []Foo
@@ -47,29 +48,30 @@ func (f FooList) formatJSON(opts *Opts) ([]byte, error) {
 }
 
 func (f FooList) formatHeader() []string {
-	return []string{
+	header := []string{
 		"ID",
 		"NAME",
 		"AGE",
 	}
-}
 
-func (f FooList) formatRows() []map[string]string {
-	data := make([]map[string]string, 0, len(f))
+	if len(f) > 0 && f[0].Profile != "" {
+		header = append([]string{"PROFILE"}, header...)
+	}
 
-	fooList := f
+	return header
+}
 
-	const txtLen = 10
+func (f FooList) formatLen() int {
+	return len(f)
+}
 
-	for i := range fooList {
-		data = append(data, map[string]string{
-			"ID":   fmt.Sprintf("%d", fooList[i].ID),
-			"NAME": fooList[i].Name,
-			"AGE":  fooList[i].Age,
-		})
+func (f FooList) formatRow(i int) map[string]string {
+	return map[string]string{
+		"PROFILE": f[i].Profile,
+		"ID":      fmt.Sprintf("%d", f[i].ID),
+		"NAME":    f[i].Name,
+		"AGE":     f[i].Age,
 	}
-
-	return data
 }
 
 func truncate(s string, length int) string {
@@ -0,0 +1,74 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type HistoryEntry struct {
+	ID       int       `json:"id"`
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"`
+	Args     []string  `json:"args"`
+	WorkDir  string    `json:"work_dir"`
+	ExitCode int       `json:"exit_code"`
+}
+
+type HistoryList []HistoryEntry
+
+func (h HistoryList) FormatJSON(opts *Opts) (io.Reader, error) {
+	return formatJSON(h, opts)
+}
+
+func (h HistoryList) FormatYAML(opts *Opts) (io.Reader, error) {
+	return formatYAML(h, opts)
+}
+
+func (h HistoryList) FormatTable(_ *Opts) (io.Reader, error) {
+	return formatTable(h)
+}
+
+func (h HistoryList) formatJSON(opts *Opts) ([]byte, error) {
+	return json.MarshalIndent(h, "", "  ")
+}
+
+func (h HistoryList) formatHeader() []string {
+	return []string{
+		"ID",
+		"TIME",
+		"STATUS",
+		"COMMAND",
+	}
+}
+
+func (h HistoryList) formatLen() int {
+	return len(h)
+}
+
+func (h HistoryList) formatRow(i int) map[string]string {
+	return map[string]string{
+		"ID":      fmt.Sprintf("%d", h[i].ID),
+		"TIME":    h[i].Time.Local().Format(time.RFC3339),
+		"STATUS":  fmt.Sprintf("%d", h[i].ExitCode),
+		"COMMAND": strings.Join(h[i].Args, " "),
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+	Updated   time.Time `json:"updated"`
+	LastHour  int       `json:"requests_last_hour"`
+	LastDay   int       `json:"requests_last_day"`
+}
+
+func (r RateLimit) FormatJSON(opts *Opts) (io.Reader, error) {
+	return formatJSON(r, opts)
+}
+
+func (r RateLimit) FormatYAML(opts *Opts) (io.Reader, error) {
+	return formatYAML(r, opts)
+}
+
+func (r RateLimit) FormatText(_ *Opts) (io.Reader, error) {
+	buf := new(bytes.Buffer)
+	tw := tabwriter.NewWriter(buf, 0, 0, 1, ' ', 0)
+
+	rows := [][2]string{
+		{"Limit:", fmt.Sprintf("%d requests", r.Limit)},
+		{"Remaining:", fmt.Sprintf("%d requests", r.Remaining)},
+		{"Resets:", r.Reset.Local().Format(time.RFC3339)},
+		{"Last hour:", fmt.Sprintf("%d requests", r.LastHour)},
+		{"Last 24 hours:", fmt.Sprintf("%d requests", r.LastDay)},
+		{"Updated:", r.Updated.Local().Format(time.RFC3339)},
+	}
+
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\n", row[0], row[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func (r RateLimit) formatJSON(opts *Opts) ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
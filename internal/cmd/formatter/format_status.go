@@ -0,0 +1,109 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+type Status struct {
+	API        APIStatus   `json:"api"`
+	StatusPage *StatusPage `json:"status_page,omitempty"`
+}
+
+type APIStatus struct {
+	URL        string `json:"url"`
+	Up         bool   `json:"up"`
+	StatusCode int    `json:"status_code,omitempty"`
+	LatencyMS  int64  `json:"latency_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type StatusPage struct {
+	URL         string     `json:"url"`
+	Indicator   string     `json:"indicator"`
+	Description string     `json:"description"`
+	Incidents   []Incident `json:"incidents"`
+	Error       string     `json:"error,omitempty"`
+}
+
+type Incident struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Impact string `json:"impact"`
+	URL    string `json:"url"`
+}
+
+func (s Status) FormatJSON(opts *Opts) (io.Reader, error) {
+	return formatJSON(s, opts)
+}
+
+func (s Status) FormatYAML(opts *Opts) (io.Reader, error) {
+	return formatYAML(s, opts)
+}
+
+func (s Status) FormatText(_ *Opts) (io.Reader, error) {
+	buf := new(bytes.Buffer)
+	tw := tabwriter.NewWriter(buf, 0, 0, 1, ' ', 0)
+
+	api := "up"
+	switch {
+	case s.API.Error != "":
+		api = "down: " + s.API.Error
+	case !s.API.Up:
+		api = fmt.Sprintf("down: HTTP %d", s.API.StatusCode)
+	}
+
+	rows := [][2]string{
+		{"API:", s.API.URL},
+		{"Status:", api},
+		{"Latency:", fmt.Sprintf("%d ms", s.API.LatencyMS)},
+	}
+
+	if p := s.StatusPage; p != nil {
+		page := p.Description
+		if p.Error != "" {
+			page = "unavailable: " + p.Error
+		}
+
+		rows = append(rows, [2]string{"Status page:", page})
+
+		for _, incident := range p.Incidents {
+			rows = append(rows, [2]string{"Incident:", fmt.Sprintf("%s (%s, %s impact) %s", incident.Name, incident.Status, incident.Impact, incident.URL)})
+		}
+	}
+
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\n", row[0], row[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func (s Status) formatJSON(opts *Opts) ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
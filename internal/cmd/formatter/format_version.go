@@ -0,0 +1,76 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+type Version struct {
+	Version     string `json:"version"`
+	Commit      string `json:"commit"`
+	Date        string `json:"date"`
+	GoVersion   string `json:"go_version"`
+	Platform    string `json:"platform"`
+	Environment string `json:"environment"`
+	BaseURL     string `json:"base_url"`
+	APIVersion  string `json:"api_version"`
+}
+
+func (v Version) FormatJSON(opts *Opts) (io.Reader, error) {
+	return formatJSON(v, opts)
+}
+
+func (v Version) FormatYAML(opts *Opts) (io.Reader, error) {
+	return formatYAML(v, opts)
+}
+
+func (v Version) FormatText(_ *Opts) (io.Reader, error) {
+	buf := new(bytes.Buffer)
+	tw := tabwriter.NewWriter(buf, 0, 0, 1, ' ', 0)
+
+	rows := [][2]string{
+		{"Version:", v.Version},
+		{"Commit:", v.Commit},
+		{"Built:", v.Date},
+		{"Go version:", v.GoVersion},
+		{"OS/Arch:", v.Platform},
+		{"Environment:", v.Environment},
+		{"API endpoint:", v.BaseURL},
+		{"API version:", v.APIVersion},
+	}
+
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\n", row[0], row[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func (v Version) formatJSON(opts *Opts) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/cmd/formatter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	envGitHubActions = "GITHUB_ACTIONS"
+	envGitHubOutput  = "GITHUB_OUTPUT"
+	envGitHubSummary = "GITHUB_STEP_SUMMARY"
+)
+
+var (
+	// workflowData escapes the message of a workflow command
+	workflowData = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+	// workflowProperty escapes the properties of a workflow command
+	workflowProperty = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// githubActions reports whether the command runs in a GitHub Actions workflow
+func githubActions() bool {
+	return os.Getenv(envGitHubActions) == "true"
+}
+
+// githubSummary appends data to the job summary as a markdown table
+func githubSummary(cmd *cobra.Command, opts *Opts, data interface{}) {
+	if !githubActions() || os.Getenv(envGitHubSummary) == "" {
+		return
+	}
+
+	table, err := formatter.Format(data, &formatter.Opts{Output: formatter.OutputMarkdown})
+	if err != nil {
+		opts.log.Debug("could not render job summary", "error", err)
+		return
+	}
+
+	if err := appendGitHubFile(envGitHubSummary, func(w io.Writer) error {
+		if _, err := fmt.Fprintf(w, "### `%s`\n\n", cmd.CommandPath()); err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(w, table); err != nil {
+			return err
+		}
+
+		_, err := fmt.Fprintln(w)
+
+		return err
+	}); err != nil {
+		opts.log.Warn("could not write job summary", "error", err)
+	}
+}
+
+// reportGitHub annotates the workflow run with the error, if any, and sets
+// the exit_code, request_id and items step outputs
+func reportGitHub(cmd *cobra.Command, opts *Opts, err error) {
+	if cmd == nil || !githubActions() {
+		return
+	}
+
+	if err != nil {
+		fmt.Fprintf(
+			opts.Stdout,
+			"::error title=%s::%s\n",
+			workflowProperty.Replace(cmd.CommandPath()),
+			workflowData.Replace(err.Error()),
+		)
+	}
+
+	if os.Getenv(envGitHubOutput) == "" {
+		return
+	}
+
+	outputs := []string{fmt.Sprintf("exit_code=%d", ExitCode(err))}
+
+	if id := requestID(err); id != "" {
+		outputs = append(outputs, "request_id="+id)
+	}
+
+	opts.statsMu.Lock()
+	items := opts.stats.items
+	opts.statsMu.Unlock()
+
+	outputs = append(outputs, fmt.Sprintf("items=%d", items))
+
+	if err := appendGitHubFile(envGitHubOutput, func(w io.Writer) error {
+		_, err := fmt.Fprintln(w, strings.Join(outputs, "\n"))
+		return err
+	}); err != nil {
+		opts.log.Warn("could not set step outputs", "error", err)
+	}
+}
+
+// appendGitHubFile calls fn with the file named by the env environment
+// variable opened for appending
+func appendGitHubFile(env string, fn func(w io.Writer) error) error {
+	file, err := os.OpenFile(os.Getenv(env), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/build"
+	"github.com/edsonmichaque/opensdk-cli/internal/golden"
+)
+
+const fixturesDir = "testdata/fixtures"
+
+// TestGolden runs commands against the fixtures and compares their output
+// with the golden files. Run it with -update after an intended change of
+// the output.
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"foo table", []string{"foo"}},
+		{"foo json", []string{"foo", "--output", "json"}},
+		{"foo yaml", []string{"foo", "--output", "yaml"}},
+		{"foo query", []string{"foo", "--output", "json", "--query", "[].name"}},
+		{"foo filter", []string{"foo", "--filter", "id == 2"}},
+		{"config get table", []string{"config", "get", "account"}},
+		{"config get json", []string{"config", "get", "account", "--output", "json"}},
+		{"config get yaml", []string{"config", "get", "account", "--output", "yaml"}},
+		{"history table", []string{"history"}},
+		{"history json", []string{"history", "--output", "json"}},
+		{"history yaml", []string{"history", "--output", "yaml"}},
+		{"audit list table", []string{"audit", "list"}},
+		{"audit list json", []string{"audit", "list", "--output", "json"}},
+		{"audit list yaml", []string{"audit", "list", "--output", "yaml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			golden.Assert(t, tt.name, runGolden(t, "config.yaml", tt.args...))
+		})
+	}
+}
+
+// TestGoldenOutputDefault runs commands that have no table with output:
+// table in the profile, which must fall back to the default of the command
+func TestGoldenOutputDefault(t *testing.T) {
+	commit, date := build.Commit, build.Date
+	build.Commit, build.Date = "0123456789abcdef", "2023-01-01T00:00:00Z"
+	t.Cleanup(func() { build.Commit, build.Date = commit, date })
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"version output table default", []string{"version"}},
+		{"history export output table default", []string{"history", "export"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			golden.Assert(t, tt.name, stableOutput(runGolden(t, "config_output_table.yaml", tt.args...)))
+		})
+	}
+}
+
+// TestGoldenVCR replays the requests of a command from a cassette
+func TestGoldenVCR(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"status vcr text", []string{"status"}},
+		{"status vcr json", []string{"status", "--output", "json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(convertFlagToEnv(cfgVCR), vcrReplay)
+			t.Setenv(convertFlagToEnv(cfgVCRCassette), filepath.Join(fixturesDir, "cassette.json"))
+			t.Setenv(convertFlagToEnv(cfgStatusPage), "https://status.example.com")
+
+			golden.Assert(t, tt.name, stableLatency(runGolden(t, "config.yaml", tt.args...)))
+		})
+	}
+}
+
+var latency = regexp.MustCompile(`(Latency:\s+|"latency_ms": )\d+`)
+
+// stableLatency replaces the latencies, which depend on the machine running
+// the tests even when the responses are replayed
+func stableLatency(out []byte) []byte {
+	return latency.ReplaceAll(out, []byte("${1}0"))
+}
+
+// stableOutput replaces what depends on the toolchain and the platform
+// running the tests
+func stableOutput(out []byte) []byte {
+	return []byte(strings.NewReplacer(
+		runtime.Version(), "GO_VERSION",
+		runtime.GOOS+"/"+runtime.GOARCH, "OS/ARCH",
+	).Replace(string(out)))
+}
+
+// runGolden runs the command with the fixture configuration and state and
+// returns its standard output
+func runGolden(t *testing.T, cfg string, args ...string) []byte {
+	t.Helper()
+
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	state := t.TempDir()
+	t.Setenv(envStateHome, state)
+	t.Setenv(envCfgHome, t.TempDir())
+
+	for _, name := range []string{historyLogFile, auditLogFile} {
+		copyFixture(t, name, filepath.Join(state, cmdName, name))
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	root, err := NewRoot(
+		WithStdin(strings.NewReader("")),
+		WithStdout(&stdout),
+		WithStderr(&stderr),
+		WithWorkDir(t.TempDir()),
+		WithConfigFile(filepath.Join(fixturesDir, cfg)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root.SetArgs(append(args, "--"+optNoInteractive))
+
+	if _, err := root.ExecuteC(); err != nil {
+		t.Fatalf("%s: %v\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+
+	return stdout.Bytes()
+}
+
+// copyFixture
+func copyFixture(t *testing.T, name, dst string) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(fixturesDir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(dst, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
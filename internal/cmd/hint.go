@@ -0,0 +1,84 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/config"
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+)
+
+// hinter is implemented by errors that know how the user can fix them
+type hinter interface {
+	Hint() string
+}
+
+// hintError attaches a remediation hint to an error
+type hintError struct {
+	err  error
+	hint string
+}
+
+// Error
+func (e hintError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap
+func (e hintError) Unwrap() error {
+	return e.err
+}
+
+// Hint
+func (e hintError) Hint() string {
+	return e.hint
+}
+
+// withHint
+func withHint(err error, hint string) error {
+	return hintError{err: err, hint: hint}
+}
+
+// errorHint returns the remediation hint carried by err, if any
+func errorHint(err error) string {
+	var h hinter
+	if errors.As(err, &h) {
+		return h.Hint()
+	}
+
+	return ""
+}
+
+// cfgHint tells the user how to fill in settings missing from the
+// configuration
+func cfgHint(err error) error {
+	switch {
+	case errors.Is(err, config.ErrMissingAccount):
+		return withHint(err, i18n.T(
+			"run `%s init`, `%s config set %s <id>` or set %s",
+			cmdName, cmdName, optAccount, convertFlagToEnv(optAccount),
+		))
+	case errors.Is(err, config.ErrMissingAccessToken):
+		return withHint(err, i18n.T(
+			"run `%s init`, `%s config set %s` or set %s",
+			cmdName, cmdName, optAccessToken, convertFlagToEnv(optAccessToken),
+		))
+	default:
+		return err
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	cfgHooks     = "hooks"
+	envHookCmd   = "OPENSDK_HOOK_COMMAND"
+	envHookPhase = "OPENSDK_HOOK_PHASE"
+	hookPost     = "post"
+	hookPre      = "pre"
+)
+
+// withHooks runs the pre and post hooks configured for the command being
+// executed, e.g.
+//
+//	hooks:
+//	  config set:
+//	    pre: ./backup.sh
+//	    post: ./notify.sh
+//
+// Post hooks only run when the command succeeds and receive its output on
+// stdin.
+func withHooks(opts *Opts) cmdOption {
+	return func(cmd *cobra.Command) {
+		var output *bytes.Buffer
+
+		cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+			if hookScript(cmd, opts, hookPost) != "" {
+				output = new(bytes.Buffer)
+				cmd.SetOut(io.MultiWriter(cmd.OutOrStdout(), output))
+			}
+
+			return runHook(cmd, opts, hookPre, nil)
+		}
+
+		cmd.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
+			return runHook(cmd, opts, hookPost, output)
+		}
+	}
+}
+
+// hookKey is the command path without the binary name, e.g. "config set"
+func hookKey(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// hookScript returns the script configured for cmd in phase
+func hookScript(cmd *cobra.Command, opts *Opts, phase string) string {
+	hooks, ok := opts.Viper.GetStringMap(cfgHooks)[hookKey(cmd)].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	script, _ := hooks[phase].(string)
+
+	return script
+}
+
+// runHook
+func runHook(cmd *cobra.Command, opts *Opts, phase string, input io.Reader) error {
+	script := hookScript(cmd, opts, phase)
+	if script == "" || opts.Viper.GetBool(optDryRun) {
+		return nil
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	opts.log.Debug("running hook", "command", hookKey(cmd), "phase", phase, "script", script)
+
+	hook := exec.Command(shell, flag, script)
+	hook.Dir = opts.WorkDir
+	hook.Stdin = input
+	hook.Stdout = opts.Stderr
+	hook.Stderr = opts.Stderr
+	hook.Env = append(
+		os.Environ(),
+		fmt.Sprintf("%s=%s", envHookCmd, hookKey(cmd)),
+		fmt.Sprintf("%s=%s", envHookPhase, phase),
+	)
+
+	if err := hook.Run(); err != nil {
+		return wrapError(exitFailure, fmt.Errorf("%s hook for %q failed: %w", phase, hookKey(cmd), err))
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// withLazyCmd adds a placeholder for the command named name. The command,
+// its subcommands and their flags are only built by loadCmds, when the
+// command is invoked.
+func withLazyCmd(opts *Opts, name string, build func() *Cmd, aliases ...string) cmdOption {
+	return func(cmd *cobra.Command) {
+		placeholder := &cobra.Command{
+			Use:     name,
+			Aliases: aliases,
+		}
+
+		opts.lazyCmds[placeholder] = build
+
+		cmd.AddCommand(placeholder)
+	}
+}
+
+// loadCmds builds the command args invoke. Anything that needs the whole
+// tree, such as the root help or completing the first word, builds every
+// command.
+func loadCmds(opts *Opts, root *cobra.Command, args []string) {
+	if len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd || args[0] == "help") {
+		args = args[1:]
+	}
+
+	found, _, err := root.Find(args)
+	if err != nil || found == root {
+		loadAllCmds(opts, root)
+		return
+	}
+
+	loadCmd(opts, found)
+}
+
+// loadAllCmds builds every command still behind a placeholder
+func loadAllCmds(opts *Opts, root *cobra.Command) {
+	for _, c := range root.Commands() {
+		loadCmd(opts, c)
+	}
+}
+
+// loadCmd replaces placeholder with the command it stands for. Commands
+// that are not placeholders are left alone.
+func loadCmd(opts *Opts, placeholder *cobra.Command) {
+	build, ok := opts.lazyCmds[placeholder]
+	delete(opts.lazyCmds, placeholder)
+
+	if !ok {
+		return
+	}
+
+	parent := placeholder.Parent()
+	parent.RemoveCommand(placeholder)
+
+	cmd := build().Command
+	parent.AddCommand(cmd)
+
+	withExamples()(cmd)
+	withSuggestions()(cmd)
+}
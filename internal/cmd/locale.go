@@ -0,0 +1,64 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+const cfgLocale = "locale"
+
+// initLocale picks the language of the messages from the locale setting,
+// or else LC_ALL, LC_MESSAGES and LANG
+func initLocale(opts *Opts) {
+	i18n.SetLanguage(i18n.Detect(opts.Viper.GetString(cfgLocale)))
+}
+
+// withLocale translates the help of the commands. Help skips the pre-run
+// hooks, so the configuration is read here as well.
+func withLocale(opts *Opts) cmdOption {
+	return func(cmd *cobra.Command) {
+		help := cmd.HelpFunc()
+
+		cmd.SetHelpFunc(func(c *cobra.Command, args []string) {
+			initCfg(opts)
+			initLocale(opts)
+
+			c.Short = i18n.T(c.Short)
+			for _, child := range c.Commands() {
+				child.Short = i18n.T(child.Short)
+			}
+
+			help(c, args)
+		})
+	}
+}
+
+// validateLocale
+func validateLocale(value string) (interface{}, error) {
+	for _, lang := range i18n.Languages() {
+		if i18n.Detect(value) == lang {
+			return value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported locale %q, use one of %s", value, strings.Join(i18n.Languages(), ", "))
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/build"
+	"github.com/edsonmichaque/opensdk-cli/internal/logging"
+	"github.com/edsonmichaque/opensdk-cli/internal/update"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cfgUpdateNotice     = "update-notice"
+	noticeCacheFile     = "update_check.json"
+	noticeCheckInterval = 24 * time.Hour
+	noticeTimeout       = 10 * time.Second
+	noticeWait          = 250 * time.Millisecond
+)
+
+// noticeCacheMu serializes the updates of the cache by the lookup and by
+// the notice
+var noticeCacheMu sync.Mutex
+
+// noticeCache records when the latest release was last looked up and when
+// the notice was last shown
+type noticeCache struct {
+	CheckedAt  time.Time `json:"checked_at"`
+	Latest     string    `json:"latest"`
+	NotifiedAt time.Time `json:"notified_at,omitempty"`
+}
+
+// versionCheck holds what the last lookup of the latest release found and
+// the lookup started by this command, if any
+type versionCheck struct {
+	cache noticeCache
+	done  chan struct{}
+	log   *logging.Logger
+}
+
+// startVersionCheck reads what the last lookup found and, once a day, looks
+// up the latest release again in the background, unless the notice is
+// disabled or stderr is not a terminal. The attempt is recorded before the
+// lookup starts, so that a command exiting before the answer does not make
+// the next one look up again. The lookup records its result in the cache
+// itself; a newer release is announced by the next command.
+func startVersionCheck(opts *Opts) *versionCheck {
+	if !noticeEnabled(opts) || !isTerminal(opts.Stderr) {
+		return nil
+	}
+
+	vc := &versionCheck{}
+	if cache, err := readNoticeCache(); err == nil {
+		vc.cache = *cache
+	}
+
+	if time.Since(vc.cache.CheckedAt) < noticeCheckInterval {
+		return vc
+	}
+
+	if err := updateNoticeCache(func(cache *noticeCache) {
+		cache.CheckedAt = time.Now()
+	}); err != nil {
+		opts.log.Debug("could not record the release lookup", "error", err)
+	}
+
+	vc.done, vc.log = make(chan struct{}), opts.log
+
+	go func() {
+		defer close(vc.done)
+
+		ctx, cancel := requestContext(context.Background(), noticeTimeout)
+		defer cancel()
+
+		release, err := update.Latest(ctx, opts.externalClient())
+		if err != nil {
+			opts.log.Debug("could not check for a new release", "error", err)
+			return
+		}
+
+		if err := updateNoticeCache(func(cache *noticeCache) {
+			cache.CheckedAt = time.Now()
+			cache.Latest = release.Version()
+		}); err != nil {
+			opts.log.Debug("could not record the latest release", "error", err)
+		}
+	}()
+
+	return vc
+}
+
+// wait gives the lookup started by this command up to noticeWait to record
+// its result, so that it never holds the exit of the command up for long
+func (vc *versionCheck) wait() {
+	if vc == nil || vc.done == nil {
+		return
+	}
+
+	select {
+	case <-vc.done:
+	case <-time.After(noticeWait):
+		vc.log.Debug("release lookup not done in time")
+	}
+}
+
+// notify prints a one-line notice, at most once a day, when the last lookup
+// found a newer release
+func (vc *versionCheck) notify(cmd *cobra.Command, opts *Opts) {
+	if vc == nil || cmd == nil || !noticeEnabled(opts) || silentSuccess(cmd, opts) {
+		return
+	}
+
+	switch cmd.Name() {
+	case "upgrade", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return
+	}
+
+	latest := vc.cache.Latest
+	if latest == "" || !update.Newer(build.Version, latest) || time.Since(vc.cache.NotifiedAt) < noticeCheckInterval {
+		return
+	}
+
+	fmt.Fprintf(
+		opts.Stderr,
+		"A new release of %s is available: %s -> %s (run `%s upgrade`)\n",
+		cmdName, build.Version, latest, cmdName,
+	)
+
+	_ = updateNoticeCache(func(cache *noticeCache) {
+		cache.NotifiedAt = time.Now()
+	})
+}
+
+// noticeEnabled
+func noticeEnabled(opts *Opts) bool {
+	if build.Version == "dev" {
+		return false
+	}
+
+	return !opts.Viper.IsSet(cfgUpdateNotice) || opts.Viper.GetBool(cfgUpdateNotice)
+}
+
+// isTerminal
+func isTerminal(w interface{}) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// readNoticeCache
+func readNoticeCache() (*noticeCache, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, noticeCacheFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var cache noticeCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+
+	return &cache, nil
+}
+
+// writeNoticeCache
+func writeNoticeCache(cache noticeCache) error {
+	dir, err := stateDir()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, noticeCacheFile), data, 0o600)
+}
+
+// updateNoticeCache applies fn to the cache on disk
+func updateNoticeCache(fn func(cache *noticeCache)) error {
+	noticeCacheMu.Lock()
+	defer noticeCacheMu.Unlock()
+
+	cache := &noticeCache{}
+	if cached, err := readNoticeCache(); err == nil {
+		cache = cached
+	}
+
+	fn(cache)
+
+	return writeNoticeCache(*cache)
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	cfgNotifyWebhook = "notify-webhook"
+	notifyTimeout    = 10 * time.Second
+)
+
+// notification describes the outcome of a command run with --notify. Text
+// makes the payload usable as a Slack incoming webhook message.
+type notification struct {
+	Text     string  `json:"text"`
+	Command  string  `json:"command"`
+	Success  bool    `json:"success"`
+	ExitCode int     `json:"exit_code"`
+	Duration float64 `json:"duration_seconds"`
+}
+
+// notifyCompletion tells the user cmd finished when --notify is set, via the
+// configured webhook or, without one, a desktop notification
+func notifyCompletion(cmd *cobra.Command, opts *Opts, err error, elapsed time.Duration) {
+	if cmd == nil || !opts.Viper.GetBool(optNotify) {
+		return
+	}
+
+	status := "succeeded"
+	if err != nil {
+		status = "failed"
+	}
+
+	n := notification{
+		Command:  hookKey(cmd),
+		Success:  err == nil,
+		ExitCode: ExitCode(err),
+		Duration: elapsed.Round(time.Millisecond).Seconds(),
+	}
+	n.Text = fmt.Sprintf("%s %s %s in %s", cmdName, n.Command, status, elapsed.Round(time.Second))
+
+	send := notifyDesktop
+	if url := opts.Viper.GetString(cfgNotifyWebhook); url != "" {
+		send = func(n notification) error {
+			return notifyWebhook(opts, url, n)
+		}
+	}
+
+	if err := send(n); err != nil {
+		fmt.Fprintf(opts.Stderr, "Could not send notification: %v\n", err)
+	}
+}
+
+// notifyWebhook
+func notifyWebhook(opts *Opts, url string, n notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := requestContext(context.Background(), notifyTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := opts.externalClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+// notifyDesktop
+func notifyDesktop(n notification) error {
+	var notifier *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		notifier = exec.Command(
+			"osascript", "-e",
+			fmt.Sprintf("display notification %q with title %q", n.Text, cmdName),
+		)
+	case "windows":
+		notifier = exec.Command(
+			"powershell", "-NoProfile", "-Command",
+			fmt.Sprintf(
+				"Add-Type -AssemblyName System.Windows.Forms; "+
+					"$n = New-Object System.Windows.Forms.NotifyIcon; "+
+					"$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; "+
+					"$n.ShowBalloonTip(5000, '%s', '%s', 'Info'); Start-Sleep -Seconds 5; $n.Dispose()",
+				cmdName, strings.ReplaceAll(n.Text, "'", "''"),
+			),
+		)
+	default:
+		notifier = exec.Command("notify-send", cmdName, n.Text)
+	}
+
+	return notifier.Run()
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const stdinFile = "-"
+
+// payloadReader returns the payload given by --from-file, reading stdin for
+// "-" or when stdin is a pipe and the flag is unset, along with its format.
+// It returns a nil reader when there is no payload.
+func payloadReader(opts *Opts) (io.Reader, string, error) {
+	name := opts.Viper.GetString(optFromFile)
+
+	switch {
+	case name == stdinFile:
+		return opts.Stdin, cfgFmtYAML, nil
+	case name != "":
+		if !filepath.IsAbs(name) && opts.WorkDir != "" {
+			name = filepath.Join(opts.WorkDir, filepath.FromSlash(name))
+		}
+
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return bytes.NewReader(data), payloadFormat(name), nil
+	case isPipe(opts.Stdin):
+		return opts.Stdin, cfgFmtYAML, nil
+	}
+
+	return nil, "", nil
+}
+
+// readPayload decodes the JSON or YAML payload into v and returns its
+// format, or an empty string when there is no payload
+func readPayload(opts *Opts, v interface{}) (string, error) {
+	r, format, err := payloadReader(opts)
+	if err != nil || r == nil {
+		return "", err
+	}
+
+	// YAML is a superset of JSON, so payloads of unknown format are read as
+	// YAML
+	p := viper.New()
+	p.SetConfigType(format)
+
+	if err := p.ReadConfig(r); err != nil {
+		return "", err
+	}
+
+	return format, p.Unmarshal(v)
+}
+
+// payloadFormat
+func payloadFormat(name string) string {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+	if _, ok := cfgFormats[ext]; ok {
+		return ext
+	}
+
+	return cfgFmtYAML
+}
+
+// isPipe reports whether r is a pipe, as when data is piped into the command
+func isPipe(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeNamedPipe != 0
+}
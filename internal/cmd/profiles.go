@@ -0,0 +1,124 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	optAllProfiles = "all-profiles"
+	optProfiles    = "profiles"
+)
+
+// profileFiles maps the profiles stored in the configuration directory to
+// their files
+func profileFiles() (map[string]string, error) {
+	dir, err := profilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := filepath.Ext(entry.Name())
+		if _, ok := cfgFormats[strings.TrimPrefix(ext, ".")]; !ok {
+			continue
+		}
+
+		files[strings.TrimSuffix(entry.Name(), ext)] = filepath.Join(dir, entry.Name())
+	}
+
+	return files, nil
+}
+
+// fanOutProfiles returns the options of each profile a read-only command
+// should run against, as chosen with --profiles or --all-profiles, or nil
+// to run against the current profile only. Flags given on the command line
+// still apply to every profile.
+func fanOutProfiles(cmd *cobra.Command, opts *Opts) (map[string]*Opts, error) {
+	names := opts.Viper.GetStringSlice(optProfiles)
+	if len(names) == 0 && !opts.Viper.GetBool(optAllProfiles) {
+		return nil, nil
+	}
+
+	files, err := profileFiles()
+	if err != nil {
+		return nil, wrapError(exitFailure, err)
+	}
+
+	if len(names) == 0 {
+		for name := range files {
+			names = append(names, name)
+		}
+
+		if len(names) == 0 {
+			return nil, newError(exitNotFound, "no profiles found")
+		}
+	}
+
+	profiles := make(map[string]*Opts, len(names))
+
+	for _, name := range names {
+		file, ok := files[name]
+		if !ok {
+			return nil, newError(exitNotFound, fmt.Sprintf("no such profile %q", name))
+		}
+
+		o := opts.fork()
+		o.profile = name
+		o.ConfigFile = file
+
+		initCfg(o)
+
+		if err := o.Viper.BindPFlags(cmd.Flags()); err != nil {
+			return nil, wrapError(exitFailure, err)
+		}
+
+		profiles[name] = o
+	}
+
+	return profiles, nil
+}
+
+// sortedProfiles returns the names of profiles in order
+func sortedProfiles(profiles map[string]*Opts) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -21,16 +21,24 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/edsonmichaque/opensdk-cli/internal/config"
+	"github.com/edsonmichaque/opensdk-cli/internal/i18n"
+)
+
+const (
+	promptConfirmation = "confirmation"
+	promptEnv          = "environment"
+	promptKey          = "key"
+	promptValue        = "value"
 )
 
 // execConfigPrompt
-func execConfigPrompt(c *config.Config) (*config.Config, string, error) {
+func execConfigPrompt(opts *Opts, c *config.Config) (*config.Config, string, error) {
 	res, err := execPrompt(
 		execAccountPrompt(c.Account),
 		execAccessTokenPrompt(c.AccessToken),
 		execBaseURLPrompt("https://example.con"),
 		execFileFmtPrompt(cfgFmtJSON),
-		execConfirmPrompt("Do you want to save?", true),
+		execConfirmPrompt(i18n.T("Do you want to save?"), true),
 	)
 	if err != nil {
 		return nil, "", err
@@ -55,7 +63,7 @@ func execConfigPrompt(c *config.Config) (*config.Config, string, error) {
 		format = value
 	}
 
-	fmt.Printf("%#v\n", cfg)
+	opts.log.Debug("prompted configuration", "account", cfg.Account, "base-url", cfg.BaseURL, "format", format)
 
 	return &cfg, format, nil
 }
@@ -67,7 +75,7 @@ func execAccessTokenPrompt(value string) runPromptFunc {
 
 		if err := survey.AskOne(
 			&survey.Input{
-				Message: "Access token",
+				Message: i18n.T("Access token"),
 				Default: value,
 			},
 			&token,
@@ -89,7 +97,7 @@ func execAccountPrompt(value string) runPromptFunc {
 
 		if err := survey.AskOne(
 			&survey.Input{
-				Message: "Account",
+				Message: i18n.T("Account"),
 				Default: value,
 			},
 			&account,
@@ -111,7 +119,7 @@ func execEnvPrompt(value string) runPromptFunc {
 
 		if err := survey.AskOne(
 			&survey.Select{
-				Message: "Environment",
+				Message: i18n.T("Environment"),
 				Options: []string{
 					envProd,
 					envSandbox,
@@ -125,7 +133,7 @@ func execEnvPrompt(value string) runPromptFunc {
 		}
 
 		return &promptRunnerResult{
-			Name:  optAccessToken,
+			Name:  promptEnv,
 			Value: env,
 		}, nil
 	})
@@ -138,7 +146,7 @@ func execBaseURLPrompt(value string) runPromptFunc {
 
 		if err := survey.AskOne(
 			&survey.Input{
-				Message: "Base URL",
+				Message: i18n.T("Base URL"),
 				Default: value,
 			},
 			&url,
@@ -153,6 +161,33 @@ func execBaseURLPrompt(value string) runPromptFunc {
 	})
 }
 
+// execOutputPrompt
+func execOutputPrompt(value string) runPromptFunc {
+	return runPromptFunc(func() (*promptRunnerResult, error) {
+		var output string
+
+		if err := survey.AskOne(
+			&survey.Select{
+				Message: i18n.T("Default output format"),
+				Options: []string{
+					outputTable,
+					outputJSON,
+					outputYAML,
+				},
+				Default: value,
+			},
+			&output,
+		); err != nil {
+			return nil, err
+		}
+
+		return &promptRunnerResult{
+			Name:  optOutput,
+			Value: output,
+		}, nil
+	})
+}
+
 // execFileFmtPrompt
 func execFileFmtPrompt(value string) runPromptFunc {
 	return runPromptFunc(func() (*promptRunnerResult, error) {
@@ -160,7 +195,7 @@ func execFileFmtPrompt(value string) runPromptFunc {
 
 		if err := survey.AskOne(
 			&survey.Select{
-				Message: "File format",
+				Message: i18n.T("File format"),
 				Options: []string{
 					cfgFmtJSON,
 					cfgFmtYAML,
@@ -180,6 +215,60 @@ func execFileFmtPrompt(value string) runPromptFunc {
 	})
 }
 
+// execCfgKeyPrompt
+func execCfgKeyPrompt(keys []string) runPromptFunc {
+	return runPromptFunc(func() (*promptRunnerResult, error) {
+		var key string
+
+		if err := survey.AskOne(
+			&survey.Select{
+				Message: i18n.T("Configuration key"),
+				Options: keys,
+			},
+			&key,
+		); err != nil {
+			return nil, err
+		}
+
+		return &promptRunnerResult{
+			Name:  promptKey,
+			Value: key,
+		}, nil
+	})
+}
+
+// execCfgValuePrompt
+func execCfgValuePrompt(key, value string, validate func(string) (interface{}, error)) runPromptFunc {
+	return runPromptFunc(func() (*promptRunnerResult, error) {
+		var input string
+
+		if err := survey.AskOne(
+			&survey.Input{
+				Message: key,
+				Default: value,
+			},
+			&input,
+			survey.WithValidator(survey.Required),
+			survey.WithValidator(func(ans interface{}) error {
+				if validate == nil {
+					return nil
+				}
+
+				_, err := validate(fmt.Sprintf("%v", ans))
+
+				return err
+			}),
+		); err != nil {
+			return nil, err
+		}
+
+		return &promptRunnerResult{
+			Name:  promptValue,
+			Value: input,
+		}, nil
+	})
+}
+
 // execConfirmPrompt
 func execConfirmPrompt(msg string, value bool) runPromptFunc {
 	return runPromptFunc(func() (*promptRunnerResult, error) {
@@ -196,12 +285,69 @@ func execConfirmPrompt(msg string, value bool) runPromptFunc {
 		}
 
 		return &promptRunnerResult{
-			Name:  "confirmation",
+			Name:  promptConfirmation,
 			Value: confirmation,
 		}, nil
 	})
 }
 
+// execTypedConfirmPrompt
+func execTypedConfirmPrompt(msg, expected string) runPromptFunc {
+	return runPromptFunc(func() (*promptRunnerResult, error) {
+		var typed string
+
+		if err := survey.AskOne(
+			&survey.Input{
+				Message: msg,
+			},
+			&typed,
+		); err != nil {
+			return nil, err
+		}
+
+		return &promptRunnerResult{
+			Name:  promptConfirmation,
+			Value: typed == expected,
+		}, nil
+	})
+}
+
+// confirmAction asks for a y/N confirmation before a destructive action.
+// --force skips the prompt; without it, --no-interactive fails. msg is
+// already translated.
+func confirmAction(opts *Opts, msg string) error {
+	return confirmWith(opts, execConfirmPrompt(msg, false))
+}
+
+// confirmTypedAction is like confirmAction but requires typing name back
+func confirmTypedAction(opts *Opts, msg, name string) error {
+	return confirmWith(opts,
+		execTypedConfirmPrompt(i18n.T("%s Type %q to confirm:", msg, name), name),
+	)
+}
+
+// confirmWith
+func confirmWith(opts *Opts, prompt promptRunner) error {
+	if opts.Viper.GetBool(optForce) {
+		return nil
+	}
+
+	if opts.Viper.GetBool(optNoInteractive) {
+		return newError(exitUsage, i18n.T("--%s is required in non-interactive mode", optForce))
+	}
+
+	res, err := execPrompt(prompt)
+	if err != nil {
+		return wrapError(exitFailure, err)
+	}
+
+	if !res.GetBool(promptConfirmation) {
+		return newError(exitFailure, i18n.T("aborted"))
+	}
+
+	return nil
+}
+
 // promptRunnerResult
 type promptRunnerResult struct {
 	Name  string
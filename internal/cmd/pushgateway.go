@@ -0,0 +1,102 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	cfgPushgateway     = "pushgateway-url"
+	pushgatewayTimeout = 5 * time.Second
+)
+
+// pushMetrics pushes the metrics of the run to the Pushgateway set by
+// pushgateway-url, grouped by job and command, e.g.
+//
+//	<url>/metrics/job/opensdk/command/config_set
+//
+// Each push replaces the metrics of the previous run of the same command.
+func pushMetrics(cmd *cobra.Command, opts *Opts, err error, elapsed time.Duration) {
+	gateway := opts.Viper.GetString(cfgPushgateway)
+	if cmd == nil || gateway == "" {
+		return
+	}
+
+	success := 0
+	if err == nil {
+		success = 1
+	}
+
+	opts.statsMu.Lock()
+	requests, items := opts.stats.requests, opts.stats.items
+	opts.statsMu.Unlock()
+
+	body := new(bytes.Buffer)
+
+	for _, m := range []struct {
+		name, help string
+		value      interface{}
+	}{
+		{"success", "Whether the last run succeeded.", success},
+		{"exit_code", "Exit code of the last run.", ExitCode(err)},
+		{"duration_seconds", "Duration of the last run.", elapsed.Seconds()},
+		{"items_processed", "Items processed by the last run.", items},
+		{"api_calls", "API calls made by the last run.", requests},
+		{"finished_timestamp_seconds", "Time the last run finished.", time.Now().Unix()},
+	} {
+		fmt.Fprintf(body, "# HELP %s_run_%s %s\n", cmdName, m.name, m.help)
+		fmt.Fprintf(body, "# TYPE %s_run_%s gauge\n", cmdName, m.name)
+		fmt.Fprintf(body, "%s_run_%s %v\n", cmdName, m.name, m.value)
+	}
+
+	target := fmt.Sprintf(
+		"%s/metrics/job/%s/command/%s",
+		strings.TrimSuffix(gateway, "/"),
+		cmdName,
+		url.PathEscape(strings.ReplaceAll(hookKey(cmd), " ", "_")),
+	)
+
+	ctx, cancel := requestContext(cmd.Context(), pushgatewayTimeout)
+	defer cancel()
+
+	req, reqErr := http.NewRequestWithContext(ctx, http.MethodPut, target, body)
+	if reqErr != nil {
+		opts.log.Warn("could not push metrics", "error", reqErr)
+		return
+	}
+
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, pushErr := opts.externalClient().Do(req)
+	if pushErr != nil {
+		opts.log.Warn("could not push metrics", "error", pushErr)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		opts.log.Warn("could not push metrics", "status", resp.Status)
+	}
+}
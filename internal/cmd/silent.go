@@ -0,0 +1,63 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+const optSilentSuccess = "silent-success"
+
+// withSilentSuccess discards the output of the command being executed when
+// --silent-success or OPENSDK_SILENT_SUCCESS is set, so that scheduled jobs
+// only print something when they fail. It must come after withHooks so that
+// post hooks still receive the output.
+func withSilentSuccess(opts *Opts) cmdOption {
+	return func(cmd *cobra.Command) {
+		preRun := cmd.PersistentPreRunE
+
+		cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+			if silentSuccess(cmd, opts) {
+				cmd.SetOut(io.Discard)
+			}
+
+			if preRun != nil {
+				return preRun(cmd, args)
+			}
+
+			return nil
+		}
+	}
+}
+
+// silentSuccess reports whether cmd runs in silent success mode
+func silentSuccess(cmd *cobra.Command, opts *Opts) bool {
+	if flag := cmd.Flags().Lookup(optSilentSuccess); flag != nil && flag.Changed {
+		silent, _ := strconv.ParseBool(flag.Value.String())
+		return silent
+	}
+
+	return opts.Viper.GetBool(optSilentSuccess)
+}
+
+// printSilentError writes err to stderr as a single line JSON object
+func printSilentError(cmd *cobra.Command, err error) {
+	encodeError(cmd, err, "")
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// stateDir returns the directory where the CLI keeps local state, such as
+// the history and the audit log, which must outlive caches:
+// $XDG_STATE_HOME/opensdk, ~/.local/state/opensdk, or
+// %LocalAppData%\opensdk on Windows. State left in the cache directory by
+// earlier versions, which also honored XDG_STATE_HOME, is moved there.
+func stateDir() (string, error) {
+	base, err := stateHome()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(base, cmdName)
+
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) && os.Getenv(envStateHome) == "" {
+		moveLegacyState(dir)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// stateHome returns the base directory of the state of applications
+func stateHome() (string, error) {
+	if dir := os.Getenv(envStateHome); dir != "" {
+		return dir, nil
+	}
+
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv(envLocalAppData); dir != "" {
+			return dir, nil
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".local", "state"), nil
+}
+
+// moveLegacyState moves the state kept in the cache directory by earlier
+// versions to dir, which does not exist yet
+func moveLegacyState(dir string) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return
+	}
+
+	legacy := filepath.Join(cache, cmdName)
+	if legacy == dir {
+		return
+	}
+
+	if _, err := os.Stat(legacy); err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+		return
+	}
+
+	_ = os.Rename(legacy, dir)
+}
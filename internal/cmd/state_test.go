@@ -0,0 +1,77 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestStateDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the state is in %LocalAppData%")
+	}
+
+	tests := []struct {
+		name      string
+		stateHome bool
+		legacy    bool
+		want      string
+	}{
+		{"default", false, false, ".local/state/opensdk"},
+		{"legacy cache", false, true, ".local/state/opensdk"},
+		{"XDG_STATE_HOME", true, true, "state/opensdk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+			t.Setenv(envStateHome, "")
+
+			if tt.stateHome {
+				t.Setenv(envStateHome, filepath.Join(home, "state"))
+			}
+
+			cache, err := os.UserCacheDir()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.legacy {
+				copyFixture(t, auditLogFile, filepath.Join(cache, cmdName, auditLogFile))
+			}
+
+			dir, err := stateDir()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := filepath.Join(home, tt.want); dir != want {
+				t.Fatalf("got %s, want %s", dir, want)
+			}
+
+			_, err = os.Stat(filepath.Join(dir, auditLogFile))
+			if moved := err == nil; moved != (tt.legacy && !tt.stateHome) {
+				t.Errorf("audit log moved: %v, want %v", moved, tt.legacy && !tt.stateHome)
+			}
+		})
+	}
+}
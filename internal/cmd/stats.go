@@ -0,0 +1,183 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"text/tabwriter"
+	"time"
+)
+
+// cmdStats accumulates what a command did, for --stats
+type cmdStats struct {
+	start          time.Time
+	setup          time.Duration
+	requests       int
+	sent           int64
+	received       int64
+	retries        int
+	rateLimitWaits int
+	rateLimitTime  time.Duration
+	apiTime        time.Duration
+	items          int
+	last           *requestTrace
+	rateLimit      *rateLimit
+}
+
+// startStats resets the counters
+func (s *runState) startStats() {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	s.stats = cmdStats{start: time.Now()}
+}
+
+// statsSetupDone marks the end of the setup phase: flag parsing and
+// configuration loading
+func (s *runState) statsSetupDone() {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	s.stats.setup = time.Since(s.stats.start)
+}
+
+// statsItems counts items processed by the command, such as the records of
+// a list or a bulk operation
+func (s *runState) statsItems(n int) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	s.stats.items += n
+}
+
+// statsRetry counts a retried request
+func (s *runState) statsRetry() {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	s.stats.retries++
+}
+
+// statsRateLimitWait counts a wait for the rate limit to reset
+func (s *runState) statsRateLimitWait(d time.Duration) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	s.stats.rateLimitWaits++
+	s.stats.rateLimitTime += d
+}
+
+// printStats writes the summary to stderr when --stats is set
+func printStats(opts *Opts) {
+	if !opts.Viper.GetBool(optStats) {
+		return
+	}
+
+	opts.statsMu.Lock()
+	defer opts.statsMu.Unlock()
+
+	stats := opts.stats
+
+	total := time.Since(stats.start)
+
+	tw := tabwriter.NewWriter(opts.Stderr, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "Stats:")
+	fmt.Fprintf(tw, "  API calls:\t%d\n", stats.requests)
+	fmt.Fprintf(tw, "  Bytes sent:\t%s\n", formatBytes(stats.sent))
+	fmt.Fprintf(tw, "  Bytes received:\t%s\n", formatBytes(stats.received))
+	fmt.Fprintf(tw, "  Retries:\t%d\n", stats.retries)
+	fmt.Fprintf(tw, "  Rate-limit waits:\t%d (%s)\n", stats.rateLimitWaits, stats.rateLimitTime.Round(time.Millisecond))
+	fmt.Fprintf(tw, "  Setup:\t%s\n", stats.setup.Round(time.Millisecond))
+	fmt.Fprintf(tw, "  API time:\t%s\n", stats.apiTime.Round(time.Millisecond))
+	fmt.Fprintf(tw, "  Total:\t%s\n", total.Round(time.Millisecond))
+
+	_ = tw.Flush()
+}
+
+// formatBytes
+func formatBytes(n int64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// statsTransport counts requests, bytes and time spent waiting on them
+type statsTransport struct {
+	base  http.RoundTripper
+	state *runState
+}
+
+// RoundTrip
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+
+	t.state.statsMu.Lock()
+	t.state.stats.requests++
+	t.state.stats.apiTime += time.Since(start)
+	t.state.stats.last = newRequestTrace(req, resp, err, start)
+
+	if req.ContentLength > 0 {
+		t.state.stats.sent += req.ContentLength
+	}
+
+	if resp != nil {
+		if rl := parseRateLimit(resp); rl != nil {
+			t.state.stats.rateLimit = rl
+		}
+	}
+	t.state.statsMu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = &countingBody{ReadCloser: resp.Body, state: t.state}
+
+	return resp, nil
+}
+
+// countingBody adds the bytes read from a response body to the stats
+type countingBody struct {
+	io.ReadCloser
+	state *runState
+}
+
+// Read
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	b.state.statsMu.Lock()
+	b.state.stats.received += int64(n)
+	b.state.statsMu.Unlock()
+
+	return n, err
+}
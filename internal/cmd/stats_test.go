@@ -0,0 +1,67 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestStatsAPIOnly checks that only the requests of httpClient count as API
+// calls
+func TestStatsAPIOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	state := newRunState()
+	state.startStats()
+
+	tests := []struct {
+		client *http.Client
+		path   string
+	}{
+		{state.httpClient(), "/api"},
+		{state.externalClient(), "/releases"},
+		{state.externalClient(), "/telemetry"},
+	}
+
+	for _, tt := range tests {
+		resp, err := tt.client.Get(server.URL + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	if state.stats.requests != 1 {
+		t.Errorf("got %d API calls, want 1", state.stats.requests)
+	}
+
+	if state.stats.received != 2 {
+		t.Errorf("got %d bytes received, want 2", state.stats.received)
+	}
+
+	if want := server.URL + "/api"; state.stats.last == nil || state.stats.last.URL != want {
+		t.Errorf("got last request %+v, want %s", state.stats.last, want)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	cfgStrict       = "strict"
+	optStrictConfig = "strict-config"
+)
+
+// cfgOnlyKeys are the settings that have no flag and are only read from the
+// configuration file or the environment
+var cfgOnlyKeys = []string{
+	cfgAliases,
+	cfgFavorites,
+	cfgHistory,
+	cfgHooks,
+	cfgNotifyWebhook,
+	cfgStrict,
+	cfgVCR,
+	cfgVCRCassette,
+	optOutput,
+}
+
+// strictEnvs are OPENSDK_* variables that do not name a setting
+var strictEnvs = map[string]struct{}{
+	envCfgFile:   {},
+	envProfile:   {},
+	envHookCmd:   {},
+	envHookPhase: {},
+}
+
+// strictCfg reports whether strict configuration mode is on, with
+// --strict-config or the strict setting
+func strictCfg(cmd *cobra.Command, opts *Opts) bool {
+	if strict, _ := cmd.Flags().GetBool(optStrictConfig); strict {
+		return true
+	}
+
+	return opts.Viper.GetBool(cfgStrict)
+}
+
+// checkStrictCfg fails in strict mode on configuration keys and OPENSDK_*
+// environment variables that no setting or flag reads, which would otherwise
+// be ignored
+func checkStrictCfg(cmd *cobra.Command, opts *Opts) error {
+	if !strictCfg(cmd, opts) {
+		return nil
+	}
+
+	known := knownCfgKeys(cmd, opts)
+
+	var problems []string
+
+	seen := make(map[string]struct{})
+
+	for _, key := range opts.Viper.AllKeys() {
+		top := strings.SplitN(key, ".", 2)[0]
+		if _, ok := seen[top]; ok {
+			continue
+		}
+
+		seen[top] = struct{}{}
+
+		if _, ok := known[top]; ok || !opts.Viper.InConfig(top) {
+			continue
+		}
+
+		problems = append(problems, fmt.Sprintf("unknown configuration key %q%s", top, suggestCfgKey(top, known)))
+	}
+
+	envs := make(map[string]string)
+
+	for _, env := range os.Environ() {
+		name := strings.SplitN(env, "=", 2)[0]
+		if !strings.HasPrefix(name, envPrefix+"_") {
+			continue
+		}
+
+		if _, ok := strictEnvs[name]; ok {
+			continue
+		}
+
+		flag, err := convertEnvToFlag(env)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("cannot read environment variable %s", name))
+			continue
+		}
+
+		if _, ok := known[flag]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown environment variable %s%s", name, suggestCfgKey(flag, known)))
+			continue
+		}
+
+		if other, ok := envs[flag]; ok {
+			problems = append(problems, fmt.Sprintf("environment variables %s and %s both set %q", other, name, flag))
+			continue
+		}
+
+		envs[flag] = name
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+
+	return wrapError(exitValidation, withHint(
+		errors.New(strings.Join(problems, "; ")),
+		fmt.Sprintf("fix the names above, or drop --%s and the %s setting to ignore them", optStrictConfig, cfgStrict),
+	))
+}
+
+// knownCfgKeys returns the settings that can be read: the configuration
+// properties, the settings without a flag and the flags of every command.
+// A profile or the environment is shared by all the commands, so a flag of
+// another command is not unknown.
+func knownCfgKeys(cmd *cobra.Command, opts *Opts) map[string]struct{} {
+	known := make(map[string]struct{})
+
+	for key := range configProps {
+		known[key] = struct{}{}
+	}
+
+	for _, key := range cfgOnlyKeys {
+		known[key] = struct{}{}
+	}
+
+	root := cmd.Root()
+	loadAllCmds(opts, root)
+
+	walkCmds(root, func(c *cobra.Command) {
+		for _, flags := range []*pflag.FlagSet{c.PersistentFlags(), c.Flags()} {
+			flags.VisitAll(func(f *pflag.Flag) {
+				known[f.Name] = struct{}{}
+			})
+		}
+	})
+
+	return known
+}
+
+// suggestCfgKey returns the known key closest to key, if any is close
+// enough to be a typo
+func suggestCfgKey(key string, known map[string]struct{}) string {
+	best, distance := "", suggestionsDistance+1
+
+	for k := range known {
+		if d := levenshtein(key, k); d < distance || d == distance && k < best {
+			best, distance = k, d
+		}
+	}
+
+	if best == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCheckStrictCfg(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    string
+		env    map[string]string
+		strict bool
+		err    string
+	}{
+		{"known keys", "account: \"1234\"\nbase-url: https://api.example.com\noutput: json\n", nil, true, ""},
+		{"flag of another command", "query: name\n", nil, true, ""},
+		{"unknown key", "acount: \"1234\"\n", nil, true, `unknown configuration key "acount" (did you mean "account"?)`},
+		{"unknown key not strict", "acount: \"1234\"\n", nil, false, ""},
+		{"strict setting", "strict: true\nacount: \"1234\"\n", nil, false, `unknown configuration key "acount"`},
+		{"known env", "", map[string]string{"OPENSDK_ACCOUNT": "1234"}, true, ""},
+		{"unknown env", "", map[string]string{"OPENSDK_ACOUNT": "1234"}, true, "unknown environment variable OPENSDK_ACOUNT"},
+		{"env not a setting", "", map[string]string{envProfile: "main"}, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			var stdout, stderr bytes.Buffer
+
+			root, _ := newTestTree(t, writeTestCfg(t, tt.cfg), &stdout, &stderr)
+
+			args := []string{"version", "--" + optNoInteractive}
+			if tt.strict {
+				args = append(args, "--"+optStrictConfig)
+			}
+
+			root.SetArgs(args)
+
+			_, err := root.ExecuteC()
+
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.err != "" && err == nil:
+				t.Errorf("got no error, want %q", tt.err)
+			case tt.err != "" && !strings.Contains(err.Error(), tt.err):
+				t.Errorf("got error %q, want %q", err, tt.err)
+			case tt.err != "" && ExitCode(err) != exitValidation:
+				t.Errorf("got exit code %d, want %d", ExitCode(err), exitValidation)
+			}
+		})
+	}
+}
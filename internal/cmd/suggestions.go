@@ -0,0 +1,144 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	suggestionsDistance = 2
+	unknownFlagPrefix   = "unknown flag: --"
+)
+
+// withSuggestions makes unknown subcommands of command groups and unknown
+// flags anywhere in the tree reply with "did you mean" suggestions. cobra
+// only does the former, and only for the root command.
+func withSuggestions() cmdOption {
+	return func(cmd *cobra.Command) {
+		cmd.SetFlagErrorFunc(suggestFlags)
+
+		walkCmds(cmd, func(c *cobra.Command) {
+			c.SuggestionsMinimumDistance = suggestionsDistance
+
+			if !c.HasParent() || !c.HasSubCommands() || c.Runnable() {
+				return
+			}
+
+			c.Args = cobra.ArbitraryArgs
+			c.RunE = func(c *cobra.Command, args []string) error {
+				if len(args) == 0 {
+					return c.Help()
+				}
+
+				return fmt.Errorf("unknown command %q for %q%s", args[0], c.CommandPath(), formatSuggestions(c.SuggestionsFor(args[0])))
+			}
+		})
+	}
+}
+
+// suggestFlags
+func suggestFlags(cmd *cobra.Command, err error) error {
+	if !strings.HasPrefix(err.Error(), unknownFlagPrefix) {
+		return err
+	}
+
+	typed := strings.TrimPrefix(err.Error(), unknownFlagPrefix)
+
+	var suggestions []string
+
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+
+		if levenshtein(typed, f.Name) <= suggestionsDistance || strings.HasPrefix(f.Name, typed) {
+			suggestions = append(suggestions, "--"+f.Name)
+		}
+	})
+
+	return fmt.Errorf("%w%s", err, formatSuggestions(suggestions))
+}
+
+// formatSuggestions renders suggestions the way cobra does
+func formatSuggestions(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString("\n\nDid you mean this?\n")
+
+	for _, s := range suggestions {
+		fmt.Fprintf(&b, "\t%v\n", s)
+	}
+
+	return b.String()
+}
+
+// walkCmds calls fn for cmd and all its subcommands
+func walkCmds(cmd *cobra.Command, fn func(*cobra.Command)) {
+	fn(cmd)
+
+	for _, child := range cmd.Commands() {
+		walkCmds(child, fn)
+	}
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// minInt
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/build"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	envOTLPEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envOTLPTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	tracerName            = "github.com/edsonmichaque/opensdk-cli"
+	tracingFlushTimeout   = 5 * time.Second
+)
+
+var (
+	tracerProvider *sdktrace.TracerProvider
+	tracingOnce    sync.Once
+)
+
+// initTracing sets up the OTLP/HTTP exporter, configured through the
+// standard OTEL_* environment variables, when an OTLP endpoint is set
+func initTracing(opts *Opts) {
+	tracingOnce.Do(func() {
+		if os.Getenv(envOTLPEndpoint) == "" && os.Getenv(envOTLPTracesEndpoint) == "" {
+			return
+		}
+
+		exporter, err := otlptracehttp.New(context.Background())
+		if err != nil {
+			opts.log.Warn("could not set up trace exporter", "error", err)
+			return
+		}
+
+		res, err := resource.Merge(
+			resource.Default(),
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceName(cmdName),
+				semconv.ServiceVersion(build.Version),
+			),
+		)
+		if err != nil {
+			res = resource.Default()
+		}
+
+		tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+		)
+
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+}
+
+// startCmdSpan starts the span covering a command execution. It is renamed
+// once the executed command is known.
+func startCmdSpan(ctx context.Context, opts *Opts) (context.Context, trace.Span) {
+	initTracing(opts)
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, cmdName)
+	opts.setCmdSpan(span)
+
+	return ctx, span
+}
+
+// endCmdSpan records the outcome of cmd on span, ends it and flushes the
+// exporter, since the process exits right after
+func endCmdSpan(span trace.Span, cmd *cobra.Command, opts *Opts, err error) {
+	if cmd != nil {
+		span.SetName(cmd.CommandPath())
+		span.SetAttributes(attribute.String("opensdk.command", hookKey(cmd)))
+	}
+
+	span.SetAttributes(attribute.Int("opensdk.exit_code", ExitCode(err)))
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+	opts.setCmdSpan(nil)
+
+	if tracerProvider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer cancel()
+
+	if err := tracerProvider.ForceFlush(ctx); err != nil {
+		opts.log.Debug("could not export traces", "error", err)
+	}
+}
+
+// setCmdSpan
+func (s *runState) setCmdSpan(span trace.Span) {
+	s.spanMu.Lock()
+	defer s.spanMu.Unlock()
+
+	s.cmdSpan = span
+}
+
+// getCmdSpan
+func (s *runState) getCmdSpan() trace.Span {
+	s.spanMu.Lock()
+	defer s.spanMu.Unlock()
+
+	return s.cmdSpan
+}
+
+// tracingTransport emits a client span for every HTTP request and
+// propagates the trace context to the server. Without an exporter, spans go
+// to the no-op tracer.
+type tracingTransport struct {
+	base  http.RoundTripper
+	state *runState
+}
+
+// RoundTrip
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if span := t.state.getCmdSpan(); !trace.SpanContextFromContext(ctx).IsValid() && span != nil {
+		ctx = trace.ContextWithSpan(ctx, span)
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(
+		ctx,
+		fmt.Sprintf("HTTP %s", req.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethod(req.Method),
+			semconv.HTTPURL(req.URL.Redacted()),
+			semconv.NetPeerName(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCode(resp.StatusCode))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
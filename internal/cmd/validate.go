@@ -0,0 +1,128 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// flagRule checks the flags of a command. Values are read from opts, so
+// settings coming from the configuration file and the environment are
+// checked as well.
+type flagRule func(cmd *cobra.Command, opts *Opts) error
+
+// validateFlags runs rules in order and returns the first failure
+func validateFlags(cmd *cobra.Command, opts *Opts, rules ...flagRule) error {
+	for _, rule := range rules {
+		if err := rule(cmd, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flagEnum requires flag to be one of values. Only a value given on the
+// command line is rejected: a default from the configuration or the
+// environment that this command does not accept, such as output: table for
+// a command without a table, falls back to the default of the flag, given
+// as if on the command line so that it takes precedence over the setting.
+func flagEnum(flag string, values ...string) flagRule {
+	return func(cmd *cobra.Command, opts *Opts) error {
+		value := opts.Viper.GetString(flag)
+
+		for _, v := range values {
+			if value == v {
+				return nil
+			}
+		}
+
+		f := cmd.Flags().Lookup(flag)
+		if f != nil && !f.Changed {
+			opts.log.Debug("ignoring unsupported configured value", "flag", flag, "value", value, "default", f.DefValue)
+
+			return cmd.Flags().Set(flag, f.DefValue)
+		}
+
+		return fmt.Errorf("invalid value %q for --%s: must be one of %s", value, flag, strings.Join(values, ", "))
+	}
+}
+
+// flagRange requires flag, when set, to be between min and max inclusive
+func flagRange(flag string, min, max int) flagRule {
+	return func(cmd *cobra.Command, opts *Opts) error {
+		if !flagSet(cmd, opts, flag) {
+			return nil
+		}
+
+		if value := opts.Viper.GetInt(flag); value < min || value > max {
+			return fmt.Errorf("invalid value %d for --%s: must be between %d and %d", value, flag, min, max)
+		}
+
+		return nil
+	}
+}
+
+// flagURL requires flag, when set, to be an absolute HTTP or HTTPS URL
+func flagURL(flag string) flagRule {
+	return func(cmd *cobra.Command, opts *Opts) error {
+		value := opts.Viper.GetString(flag)
+		if value == "" {
+			return nil
+		}
+
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid value %q for --%s: must be an http or https URL", value, flag)
+		}
+
+		return nil
+	}
+}
+
+// flagsMutuallyExclusive allows at most one of flags to be set
+func flagsMutuallyExclusive(flags ...string) flagRule {
+	return func(cmd *cobra.Command, opts *Opts) error {
+		var set []string
+
+		for _, flag := range flags {
+			if flagSet(cmd, opts, flag) {
+				set = append(set, "--"+flag)
+			}
+		}
+
+		if len(set) > 1 {
+			return fmt.Errorf("%s cannot be used together", strings.Join(set, " and "))
+		}
+
+		return nil
+	}
+}
+
+// flagSet reports whether flag was given on the command line or set in the
+// configuration or the environment
+func flagSet(cmd *cobra.Command, opts *Opts, flag string) bool {
+	if f := cmd.Flags().Lookup(flag); f != nil && f.Changed {
+		return true
+	}
+
+	return opts.Viper.IsSet(flag)
+}
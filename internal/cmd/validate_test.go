@@ -0,0 +1,107 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestValidateFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		rule flagRule
+		args []string
+		cfg  map[string]interface{}
+		err  string
+	}{
+		{"enum", flagEnum(optOutput, outputJSON, outputYAML), []string{"--output", "yaml"}, nil, ""},
+		{"enum invalid", flagEnum(optOutput, outputJSON, outputYAML), []string{"--output", "table"}, nil, `invalid value "table" for --output`},
+		{"enum configured", flagEnum(optOutput, outputJSON, outputYAML), nil, map[string]interface{}{optOutput: outputTable}, ""},
+		{"range", flagRange(optPort, 1, 65535), []string{"--port", "8080"}, nil, ""},
+		{"range unset", flagRange(optPort, 1, 65535), nil, nil, ""},
+		{"range high", flagRange(optPort, 1, 65535), []string{"--port", "65536"}, nil, "must be between 1 and 65535"},
+		{"range configured", flagRange(optPort, 1, 65535), nil, map[string]interface{}{optPort: 0}, "must be between 1 and 65535"},
+		{"url", flagURL(optBaseURL), []string{"--base-url", "https://api.example.com"}, nil, ""},
+		{"url scheme", flagURL(optBaseURL), []string{"--base-url", "ftp://api.example.com"}, nil, "must be an http or https URL"},
+		{"url relative", flagURL(optBaseURL), []string{"--base-url", "/v1"}, nil, "must be an http or https URL"},
+		{"exclusive", flagsMutuallyExclusive(optForce, optConfirm), []string{"--force"}, nil, ""},
+		{"exclusive configured", flagsMutuallyExclusive(optForce, optConfirm), []string{"--force"}, map[string]interface{}{optConfirm: true}, "cannot be used together"},
+		{"exclusive both", flagsMutuallyExclusive(optForce, optConfirm), []string{"--force", "--confirm"}, nil, "--force and --confirm cannot be used together"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "test"}
+			cmd.Flags().String(optOutput, outputJSON, "")
+			cmd.Flags().Int(optPort, 0, "")
+			cmd.Flags().String(optBaseURL, "", "")
+			cmd.Flags().Bool(optForce, false, "")
+			cmd.Flags().Bool(optConfirm, false, "")
+
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			opts := &Opts{Viper: newViper(), runState: newRunState()}
+			if err := opts.Viper.MergeConfigMap(tt.cfg); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := opts.Viper.BindPFlags(cmd.Flags()); err != nil {
+				t.Fatal(err)
+			}
+
+			err := validateFlags(cmd, opts, tt.rule)
+
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.err != "" && err == nil:
+				t.Errorf("got no error, want %q", tt.err)
+			case tt.err != "" && !strings.Contains(err.Error(), tt.err):
+				t.Errorf("got error %q, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+// TestFlagEnumConfiguredFallback checks that an unsupported configured value
+// is replaced with the default of the flag
+func TestFlagEnumConfiguredFallback(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String(optOutput, outputJSON, "")
+
+	opts := &Opts{Viper: newViper(), runState: newRunState()}
+	if err := opts.Viper.MergeConfigMap(map[string]interface{}{optOutput: outputTable}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := opts.Viper.BindPFlags(cmd.Flags()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateFlags(cmd, opts, flagEnum(optOutput, outputJSON, outputYAML)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := opts.Viper.GetString(optOutput); got != outputJSON {
+		t.Errorf("got output %q, want %q", got, outputJSON)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/edsonmichaque/opensdk-cli/internal/logging"
+)
+
+const (
+	cfgVCR          = "vcr"
+	cfgVCRCassette  = "vcr-cassette"
+	defaultCassette = "opensdk-cassette.json"
+	vcrRecord       = "record"
+	vcrReplay       = "replay"
+)
+
+// vcrInteraction is a request and the response it got, with secrets
+// redacted
+type vcrInteraction struct {
+	Request  vcrMessage `json:"request"`
+	Response vcrMessage `json:"response"`
+}
+
+// vcrMessage holds either side of an interaction. Binary bodies are
+// base64-encoded.
+type vcrMessage struct {
+	Method     string              `json:"method,omitempty"`
+	URL        string              `json:"url,omitempty"`
+	StatusCode int                 `json:"status_code,omitempty"`
+	Headers    map[string][]string `json:"headers"`
+	Body       string              `json:"body,omitempty"`
+	Binary     bool                `json:"binary,omitempty"`
+}
+
+// vcrTransport records the requests to a cassette file, with
+// OPENSDK_VCR=record, or answers them from it without touching the
+// network, with OPENSDK_VCR=replay. The cassette is opensdk-cassette.json
+// unless OPENSDK_VCR_CASSETTE names another file.
+type vcrTransport struct {
+	base http.RoundTripper
+	log  *logging.Logger
+	mode string
+	path string
+
+	mu           sync.Mutex
+	interactions []vcrInteraction
+	used         []bool
+	err          error
+}
+
+// newVCRTransport wraps base in a vcrTransport when record or replay mode
+// is set, and returns base otherwise
+func newVCRTransport(log *logging.Logger, base http.RoundTripper) http.RoundTripper {
+	mode := os.Getenv(convertFlagToEnv(cfgVCR))
+
+	switch mode {
+	case "":
+		return base
+	case vcrRecord, vcrReplay:
+	default:
+		log.Warn("ignoring unknown vcr mode", "mode", mode)
+		return base
+	}
+
+	t := &vcrTransport{
+		base: base,
+		log:  log,
+		mode: mode,
+		path: os.Getenv(convertFlagToEnv(cfgVCRCassette)),
+	}
+
+	if t.path == "" {
+		t.path = defaultCassette
+	}
+
+	if mode == vcrReplay {
+		t.err = t.load()
+	}
+
+	log.Debug("vcr enabled", "mode", mode, "cassette", t.path)
+
+	return t
+}
+
+// RoundTrip
+func (t *vcrTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == vcrReplay {
+		return t.replay(req)
+	}
+
+	return t.record(req)
+}
+
+// replay answers req with the first unused interaction recorded for the
+// same method and URL
+func (t *vcrTransport) replay(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	target := vcrURL(req.URL)
+
+	for i, interaction := range t.interactions {
+		if t.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != target {
+			continue
+		}
+
+		t.used[i] = true
+
+		body, err := interaction.Response.body()
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header(interaction.Response.Headers),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("vcr: no recorded interaction for %s %s in %s", req.Method, target, t.path)
+}
+
+// record sends req and appends the interaction to the cassette
+func (t *vcrTransport) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		reqBody = data
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	request := newVCRMessage(req.Header, reqBody)
+	request.Method = req.Method
+	request.URL = vcrURL(req.URL)
+
+	response := newVCRMessage(resp.Header, respBody)
+	response.StatusCode = resp.StatusCode
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.interactions = append(t.interactions, vcrInteraction{Request: request, Response: response})
+
+	if err := t.save(); err != nil {
+		t.log.Warn("could not write vcr cassette", "path", t.path, "error", err)
+	}
+
+	return resp, nil
+}
+
+// load reads the cassette
+func (t *vcrTransport) load() error {
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		return fmt.Errorf("vcr: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &t.interactions); err != nil {
+		return fmt.Errorf("vcr: %s: %w", t.path, err)
+	}
+
+	t.used = make([]bool, len(t.interactions))
+
+	return nil
+}
+
+// save writes the cassette
+func (t *vcrTransport) save() error {
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.path, append(data, '\n'), 0o600)
+}
+
+// newVCRMessage redacts the sensitive headers and, for JSON objects, the
+// sensitive fields of the body
+func newVCRMessage(h http.Header, body []byte) vcrMessage {
+	msg := vcrMessage{Headers: redactHeaders(h)}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err == nil {
+		if data, err := json.Marshal(redactSettings(fields)); err == nil {
+			body = data
+		}
+	}
+
+	if utf8.Valid(body) {
+		msg.Body = string(body)
+	} else {
+		msg.Body = base64.StdEncoding.EncodeToString(body)
+		msg.Binary = true
+	}
+
+	return msg
+}
+
+// body returns the decoded body of msg
+func (msg vcrMessage) body() ([]byte, error) {
+	if msg.Binary {
+		return base64.StdEncoding.DecodeString(msg.Body)
+	}
+
+	return []byte(msg.Body), nil
+}
+
+// vcrURL returns u with the sensitive query parameters redacted
+func vcrURL(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+
+	query := clean.Query()
+	for key := range query {
+		if isSensitive(key) {
+			query.Set(key, redacted)
+		}
+	}
+
+	clean.RawQuery = query.Encode()
+
+	return clean.String()
+}
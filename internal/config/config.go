@@ -22,13 +22,13 @@ import (
 	"github.com/spf13/viper"
 )
 
-func Init() (*Config, error) {
-	return LoadWithValidation(true)
+func Init(v *viper.Viper) (*Config, error) {
+	return LoadWithValidation(v, true)
 }
 
-func LoadWithValidation(validate bool) (*Config, error) {
+func LoadWithValidation(v *viper.Viper, validate bool) (*Config, error) {
 	var cfg Config
-	if err := viper.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
 
@@ -41,20 +41,26 @@ func LoadWithValidation(validate bool) (*Config, error) {
 	return &cfg, nil
 }
 
+var (
+	ErrMissingAccount     = errors.New("account id is required")
+	ErrMissingAccessToken = errors.New("access token is required")
+)
+
 type Config struct {
 	Account     string `mapstructure:"account"`
 	Sandbox     bool   `mapstructure:"sandbox"`
 	AccessToken string `mapstructure:"access-token"`
 	BaseURL     string `mapstructure:"base-url"`
+	Output      string `mapstructure:"output"`
 }
 
 func (c Config) Validate() error {
 	if c.Account == "" {
-		return errors.New("account id is required")
+		return ErrMissingAccount
 	}
 
 	if c.AccessToken == "" {
-		return errors.New("access token is required")
+		return ErrMissingAccessToken
 	}
 
 	return nil
@@ -0,0 +1,69 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package golden compares test output with the golden files kept under
+// testdata/golden. Run the tests with -update to rewrite the golden files
+// from the current output.
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Dir is the directory holding the golden files, relative to the package
+// under test
+const Dir = "testdata/golden"
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// Path returns the golden file for name
+func Path(name string) string {
+	return filepath.Join(Dir, strings.NewReplacer(" ", "_", "/", "_").Replace(name)+".golden")
+}
+
+// Assert fails t when got differs from the golden file for name, or
+// rewrites the golden file with -update
+func Assert(t testing.TB, name string, got []byte) {
+	t.Helper()
+
+	path := Path(name)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run the tests with -update to create it)", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run the tests with -update to accept it)\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
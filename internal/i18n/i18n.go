@@ -0,0 +1,116 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package i18n translates the messages of the CLI. Messages are looked up
+// by their English text, so untranslated ones fall back to English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// English is the language the messages are written in
+	English = "en"
+	// Portuguese
+	Portuguese = "pt"
+)
+
+var (
+	catalogs = map[string]map[string]string{
+		English:    {},
+		Portuguese: pt,
+	}
+
+	mu      sync.RWMutex
+	current = English
+)
+
+// localeEnvs are the variables the locale is read from, in order of
+// precedence
+var localeEnvs = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// Detect returns the language to use: locale when set, or else the one of
+// the environment
+func Detect(locale string) string {
+	if locale != "" {
+		return language(locale)
+	}
+
+	for _, env := range localeEnvs {
+		if value := os.Getenv(env); value != "" {
+			return language(value)
+		}
+	}
+
+	return English
+}
+
+// Languages returns the supported languages
+func Languages() []string {
+	return []string{English, Portuguese}
+}
+
+// SetLanguage sets the language of the messages. Unsupported languages
+// fall back to English.
+func SetLanguage(lang string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := catalogs[lang]; !ok {
+		lang = English
+	}
+
+	current = lang
+}
+
+// T translates msg, then formats it with args when given
+func T(msg string, args ...interface{}) string {
+	mu.RLock()
+	translated, ok := catalogs[current][msg]
+	mu.RUnlock()
+
+	if !ok {
+		translated = msg
+	}
+
+	if len(args) == 0 {
+		return translated
+	}
+
+	return fmt.Sprintf(translated, args...)
+}
+
+// language turns a locale such as pt_BR.UTF-8 into its language
+func language(locale string) string {
+	parts := strings.FieldsFunc(locale, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == '@'
+	})
+	if len(parts) == 0 {
+		return English
+	}
+
+	lang := strings.ToLower(parts[0])
+
+	if lang == "c" || lang == "posix" {
+		return English
+	}
+
+	return lang
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package i18n
+
+// pt holds the Portuguese translations
+var pt = map[string]string{
+	// Messages
+	"Error:":                       "Erro:",
+	"Hint:":                        "Dica:",
+	"Run '%v --help' for usage.\n": "Execute '%v --help' para ver como usar.\n",
+	"aborted":                      "cancelado",
+	"--%s is required in non-interactive mode":                                                         "--%s é obrigatório no modo não interativo",
+	"run `%s init`, `%s config set %s <id>` or set %s":                                                 "execute `%s init`, `%s config set %s <id>` ou defina %s",
+	"run `%s init`, `%s config set %s` or set %s":                                                      "execute `%s init`, `%s config set %s` ou defina %s",
+	"check your access token: run `%s init` or `%s config set %s`":                                     "verifique o token de acesso: execute `%s init` ou `%s config set %s`",
+	"the access token cannot use this account; check the %s setting of the profile":                    "o token de acesso não pode usar esta conta; verifique a definição %s do perfil",
+	"did you mean %q without the trailing dot?":                                                        "queria dizer %q sem o ponto final?",
+	"check the name or ID, and that it belongs to the account":                                         "verifique o nome ou o ID, e se pertence à conta",
+	"check the values sent; run with --%s debug to see the API response":                               "verifique os valores enviados; execute com --%s debug para ver a resposta da API",
+	"the rate limit was reached; wait a moment and try again":                                          "o limite de pedidos foi atingido; aguarde um momento e tente novamente",
+	"the API failed to handle the request; try again later and quote the request ID when reporting it": "a API não conseguiu processar o pedido; tente mais tarde e indique o ID do pedido ao reportar o problema",
+
+	// Prompts
+	"Access token":              "Token de acesso",
+	"Account":                   "Conta",
+	"Base URL":                  "URL base",
+	"Configuration key":         "Chave de configuração",
+	"Default output format":     "Formato de saída predefinido",
+	"Do you want to save?":      "Deseja guardar?",
+	"Environment":               "Ambiente",
+	"File format":               "Formato do ficheiro",
+	"%s Type %q to confirm:":    "%s Escreva %q para confirmar:",
+	"Delete alias \"%s\"?":      "Apagar o atalho \"%s\"?",
+	"Overwrite %s?":             "Substituir %s?",
+	"Remove favorite \"%s\"?":   "Remover o favorito \"%s\"?",
+	"Run `%s %s`?":              "Executar `%s %s`?",
+	"Upgrade %s from %s to %s?": "Atualizar %s de %s para %s?",
+	"This removes the extension and its files.": "Isto remove a extensão e os seus ficheiros.",
+
+	// Commands
+	"Check version":                       "Ver a versão",
+	"Check whether the API is up":         "Verificar se a API está disponível",
+	"Collect diagnostics for bug reports": "Recolher diagnósticos para relatórios de erros",
+	"Create a command alias":              "Criar um atalho de comando",
+	"Create a tarball with version, redacted configuration, logs and the last request": "Criar um arquivo com a versão, a configuração sem segredos, os registos e o último pedido",
+	"Create your first profile":                           "Criar o seu primeiro perfil",
+	"Delete a command alias":                              "Apagar um atalho de comando",
+	"Develop against webhook events":                      "Desenvolver com eventos de webhook",
+	"Export command history for audits":                   "Exportar o histórico de comandos para auditorias",
+	"Generate man pages":                                  "Gerar páginas man",
+	"Generate markdown reference":                         "Gerar referência em markdown",
+	"Generate reference documentation":                    "Gerar documentação de referência",
+	"Generate shell completion scripts":                   "Gerar scripts de autocompletar para a shell",
+	"Initialize configuration":                            "Inicializar a configuração",
+	"Inspect the log of changes made from this machine":   "Inspecionar o registo de alterações feitas a partir desta máquina",
+	"Install an extension from a git repository":          "Instalar uma extensão a partir de um repositório git",
+	"List accounts":                                       "Listar contas",
+	"List command aliases":                                "Listar atalhos de comando",
+	"List installed extensions":                           "Listar extensões instaladas",
+	"List favorites":                                      "Listar favoritos",
+	"Manage shortcuts for frequently used names and IDs":  "Gerir atalhos para nomes e IDs usados com frequência",
+	"Remove a favorite":                                   "Remover um favorito",
+	"Save a favorite":                                     "Guardar um favorito",
+	"List recorded changes":                               "Listar alterações registadas",
+	"Manage anonymous usage telemetry":                    "Gerir a telemetria anónima de utilização",
+	"Manage command aliases":                              "Gerir atalhos de comando",
+	"Manage configurations":                               "Gerir configurações",
+	"Manage extensions":                                   "Gerir extensões",
+	"Inspect the API rate limit":                          "Inspecionar o limite de pedidos da API",
+	"Show the remaining requests and recent consumption":  "Mostrar os pedidos restantes e o consumo recente",
+	"Receive webhook events on a local port":              "Receber eventos de webhook numa porta local",
+	"Remove an installed extension":                       "Remover uma extensão instalada",
+	"Run a command from history again":                    "Executar novamente um comando do histórico",
+	"Show commands run from this machine":                 "Mostrar os comandos executados nesta máquina",
+	"Show copy-pasteable recipes":                         "Mostrar receitas prontas a copiar",
+	"Show whether telemetry is enabled and what it sends": "Mostrar se a telemetria está ativa e o que envia",
+	"Start an interactive shell":                          "Iniciar uma shell interativa",
+	"Upgrade to the latest release":                       "Atualizar para a versão mais recente",
+}
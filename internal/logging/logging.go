@@ -0,0 +1,243 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel
+func ParseLevel(s string) (Level, error) {
+	for level, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return level, nil
+		}
+	}
+
+	return LevelWarn, fmt.Errorf("invalid log level %q", s)
+}
+
+type Format string
+
+const (
+	FormatText = Format("text")
+	FormatJSON = Format("json")
+)
+
+// ParseFormat
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatText, FormatJSON:
+		return f, nil
+	}
+
+	return FormatText, fmt.Errorf("invalid log format %q", s)
+}
+
+// Logger writes leveled records as logfmt-style text or JSON lines. Extra
+// fields are given as alternating keys and values. Records are also
+// written, at every level, to the file set with SetFile.
+type Logger struct {
+	mu     sync.Mutex
+	out    io.Writer
+	file   io.WriteCloser
+	level  Level
+	format Format
+}
+
+// New
+func New(out io.Writer, level Level, format Format) *Logger {
+	return &Logger{
+		out:    out,
+		level:  level,
+		format: format,
+	}
+}
+
+var std = New(os.Stderr, LevelWarn, FormatText)
+
+// Default returns the logger used by the package-level functions
+func Default() *Logger {
+	return std
+}
+
+// SetOutput
+func (l *Logger) SetOutput(out io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.out = out
+}
+
+// SetFile tees the records to file, closing the previous one, if any. A nil
+// file stops the tee.
+func (l *Logger) SetFile(file io.WriteCloser) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file != nil {
+		_ = l.file.Close()
+	}
+
+	l.file = file
+}
+
+// SetLevel
+func (l *Logger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level = level
+}
+
+// SetFormat
+func (l *Logger) SetFormat(format Format) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.format = format
+}
+
+// Enabled reports whether records at level are written
+func (l *Logger) Enabled(level Level) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return level >= l.level
+}
+
+func (l *Logger) Debug(msg string, kv ...interface{}) { l.log(LevelDebug, msg, kv) }
+func (l *Logger) Info(msg string, kv ...interface{})  { l.log(LevelInfo, msg, kv) }
+func (l *Logger) Warn(msg string, kv ...interface{})  { l.log(LevelWarn, msg, kv) }
+func (l *Logger) Error(msg string, kv ...interface{}) { l.log(LevelError, msg, kv) }
+
+// log
+func (l *Logger) log(level Level, msg string, kv []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if level < l.level && l.file == nil {
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	var line []byte
+	if l.format == FormatJSON {
+		line = jsonRecord(now, level, msg, kv)
+	} else {
+		line = textRecord(now, level, msg, kv)
+	}
+
+	if level >= l.level {
+		_, _ = l.out.Write(line)
+	}
+
+	if l.file != nil {
+		_, _ = l.file.Write(line)
+	}
+}
+
+// jsonRecord
+func jsonRecord(now string, level Level, msg string, kv []interface{}) []byte {
+	record := map[string]interface{}{
+		"time":  now,
+		"level": level.String(),
+		"msg":   msg,
+	}
+
+	for i := 0; i < len(kv); i += 2 {
+		record[fmt.Sprint(kv[i])] = value(kv, i+1)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return textRecord(now, level, msg, kv)
+	}
+
+	return append(data, '\n')
+}
+
+// textRecord
+func textRecord(now string, level Level, msg string, kv []interface{}) []byte {
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintf(buf, "time=%s level=%s msg=%s", now, level, quote(msg))
+
+	for i := 0; i < len(kv); i += 2 {
+		fmt.Fprintf(buf, " %v=%s", kv[i], quote(fmt.Sprint(value(kv, i+1))))
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes()
+}
+
+// value returns the i-th element of kv, tolerating a missing final value
+func value(kv []interface{}, i int) interface{} {
+	if i >= len(kv) {
+		return "!MISSING"
+	}
+
+	if err, ok := kv[i].(error); ok {
+		return err.Error()
+	}
+
+	return kv[i]
+}
+
+// quote
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+func Debug(msg string, kv ...interface{}) { std.log(LevelDebug, msg, kv) }
+func Info(msg string, kv ...interface{})  { std.log(LevelInfo, msg, kv) }
+func Warn(msg string, kv ...interface{})  { std.log(LevelWarn, msg, kv) }
+func Error(msg string, kv ...interface{}) { std.log(LevelError, msg, kv) }
@@ -0,0 +1,404 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	Repo          = "edsonmichaque/opensdk-cli"
+	Binary        = "opensdk"
+	checksumsFile = "checksums.txt"
+	signatureFile = checksumsFile + ".sig"
+	latestURL     = "https://api.github.com/repos/" + Repo + "/releases/latest"
+)
+
+var (
+	ErrNoAsset      = errors.New("no release artifact for this platform")
+	ErrBadSignature = errors.New("invalid signature of " + checksumsFile)
+)
+
+// releaseKey is the base64 Ed25519 public key the checksums of every
+// release are signed with, see hack/sign and docs/release-signing.md. It is
+// empty until the maintainers provision the key.
+//
+//go:embed release.pub
+var releaseKey string
+
+// publicKey verifies the signature of the checksums, when a release key is
+// provisioned
+var publicKey = mustPublicKey(releaseKey)
+
+// mustPublicKey decodes a base64 Ed25519 public key, or returns nil for an
+// empty one
+func mustPublicKey(s string) ed25519.PublicKey {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		panic("update: invalid release public key")
+	}
+
+	return ed25519.PublicKey(key)
+}
+
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Version returns the release version without the leading v
+func (r Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Latest fetches the latest published release
+func Latest(ctx context.Context, client *http.Client) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching latest release: %s", resp.Status)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, err
+	}
+
+	return &release, nil
+}
+
+// Newer reports whether latest is a newer version than current. Development
+// builds are always considered outdated.
+func Newer(current, latest string) bool {
+	cur, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+
+	lat, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+
+	for i := range cur {
+		if lat[i] != cur[i] {
+			return lat[i] > cur[i]
+		}
+	}
+
+	return false
+}
+
+// parseVersion
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+
+	fields := strings.Split(v, ".")
+	if len(fields) != len(parts) {
+		return parts, false
+	}
+
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false
+		}
+
+		parts[i] = n
+	}
+
+	return parts, true
+}
+
+// asset returns the archive built for the running platform
+func (r Release) asset() (*Asset, error) {
+	goos := runtime.GOOS
+	if goos == "darwin" {
+		goos = "macos"
+	}
+
+	suffix := fmt.Sprintf("-%s-%s", goos, runtime.GOARCH)
+
+	for i := range r.Assets {
+		name := strings.TrimSuffix(strings.TrimSuffix(r.Assets[i].Name, ".tar.gz"), ".zip")
+		if strings.HasSuffix(name, suffix) {
+			return &r.Assets[i], nil
+		}
+	}
+
+	return nil, ErrNoAsset
+}
+
+// checksum returns the published SHA-256 of the asset named name, once the
+// signature of the checksums was verified with the release public key.
+// Builds without a release key only check the checksums.
+func (r Release) checksum(ctx context.Context, client *http.Client, name string) (string, error) {
+	sums, err := r.download(ctx, client, checksumsFile)
+	if err != nil {
+		return "", err
+	}
+
+	if publicKey == nil {
+		return lookupChecksum(sums, name)
+	}
+
+	sig, err := r.download(ctx, client, signatureFile)
+	if err != nil {
+		return "", err
+	}
+
+	if err := verifySignature(sums, sig); err != nil {
+		return "", err
+	}
+
+	return lookupChecksum(sums, name)
+}
+
+// download fetches the asset named name
+func (r Release) download(ctx context.Context, client *http.Client, name string) ([]byte, error) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return download(ctx, client, a.URL)
+		}
+	}
+
+	return nil, fmt.Errorf("release has no %s", name)
+}
+
+// verifySignature checks sig, the base64 Ed25519 signature of data
+func verifySignature(data, sig []byte) error {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil || !ed25519.Verify(publicKey, data, raw) {
+		return ErrBadSignature
+	}
+
+	return nil
+}
+
+// lookupChecksum returns the entry for name in sums, in the format of
+// sha256sum
+func lookupChecksum(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name {
+			return fields[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("%s has no entry for %s", checksumsFile, name)
+}
+
+// Install downloads the release artifact for the running platform,
+// verifies it against the signed checksums and replaces the executable at
+// dst with the binary it contains
+func (r Release) Install(ctx context.Context, client *http.Client, dst string) error {
+	asset, err := r.asset()
+	if err != nil {
+		return err
+	}
+
+	want, err := r.checksum(ctx, client, asset.Name)
+	if err != nil {
+		return err
+	}
+
+	archive, err := download(ctx, client, asset.URL)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", asset.Name, want, got)
+	}
+
+	bin, err := extract(asset.Name, archive)
+	if err != nil {
+		return err
+	}
+
+	return replace(dst, bin)
+}
+
+// download
+func download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// extract returns the binary stored in the archive
+func extract(name string, archive []byte) ([]byte, error) {
+	bin := Binary
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+
+	if strings.HasSuffix(name, ".zip") {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range zr.File {
+			if path.Base(f.Name) != bin {
+				continue
+			}
+
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+
+			return io.ReadAll(rc)
+		}
+
+		return nil, fmt.Errorf("%s not found in %s", bin, name)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s not found in %s", bin, name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == bin {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replace atomically swaps the executable at dst for bin. The running
+// executable on Windows cannot be overwritten, so it is moved aside first
+// and moved back when the new one cannot take its place.
+func replace(dst string, bin []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+Binary+"-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bin); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return err
+	}
+
+	if runtime.GOOS != "windows" {
+		return os.Rename(tmp.Name(), dst)
+	}
+
+	old := dst + ".old"
+	_ = os.Remove(old)
+
+	if err := os.Rename(dst, old); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		if restoreErr := os.Rename(old, dst); restoreErr != nil {
+			return fmt.Errorf("%w; restoring %s: %v", err, dst, restoreErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,301 @@
+// Copyright 2023 Edson Michaque
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		current string
+		latest  string
+		want    bool
+	}{
+		{"1.2.3", "1.2.4", true},
+		{"1.2.3", "1.3.0", true},
+		{"1.2.3", "2.0.0", true},
+		{"v1.2.3", "1.10.0", true},
+		{"1.2.3", "1.2.3", false},
+		{"1.2.4", "1.2.3", false},
+		{"2.0.0", "1.9.9", false},
+		{"1.2.3", "v1.2.3-rc.1", false},
+		{"dev", "1.0.0", true},
+		{"1.0.0", "latest", false},
+	}
+
+	for _, tt := range tests {
+		if got := Newer(tt.current, tt.latest); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    [3]int
+		ok      bool
+	}{
+		{"1.2.3", [3]int{1, 2, 3}, true},
+		{"v10.0.1", [3]int{10, 0, 1}, true},
+		{"1.2.3-rc.1", [3]int{1, 2, 3}, true},
+		{"1.2.3+build.5", [3]int{1, 2, 3}, true},
+		{"1.2", [3]int{}, false},
+		{"1.2.3.4", [3]int{}, false},
+		{"1.x.3", [3]int{}, false},
+		{"dev", [3]int{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseVersion(tt.version)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseVersion(%q) = %v, %v, want %v, %v", tt.version, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestExtract(t *testing.T) {
+	bin := binaryName()
+
+	tests := []struct {
+		name    string
+		archive []byte
+		want    string
+		wantErr bool
+	}{
+		{"opensdk.tar.gz", tarGz(t, map[string]string{"opensdk-1.0.0/" + bin: "binary", "opensdk-1.0.0/LICENSE": "license"}), "binary", false},
+		{"opensdk.tar.gz", tarGz(t, map[string]string{"LICENSE": "license"}), "", true},
+		{"opensdk.zip", zipped(t, map[string]string{"opensdk-1.0.0/" + bin: "binary", "opensdk-1.0.0/LICENSE": "license"}), "binary", false},
+		{"opensdk.zip", zipped(t, map[string]string{"LICENSE": "license"}), "", true},
+		{"opensdk.tar.gz", []byte("not an archive"), "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := extract(tt.name, tt.archive)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("extract(%q) error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+
+		if string(got) != tt.want {
+			t.Errorf("extract(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := publicKey
+	publicKey = pub
+	t.Cleanup(func() { publicKey = key })
+
+	sums := []byte("0123abcd  opensdk-1.0.0-linux-amd64.tar.gz\n4567ef01  opensdk-1.0.0-windows-amd64.zip\n")
+	sig := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, sums)) + "\n")
+
+	tests := []struct {
+		desc    string
+		files   map[string][]byte
+		name    string
+		want    string
+		wantErr error
+	}{
+		{"signed", map[string][]byte{checksumsFile: sums, signatureFile: sig}, "opensdk-1.0.0-windows-amd64.zip", "4567ef01", nil},
+		{"tampered", map[string][]byte{checksumsFile: append([]byte("ffff  evil.tar.gz\n"), sums...), signatureFile: sig}, "evil.tar.gz", "", ErrBadSignature},
+		{"bad signature", map[string][]byte{checksumsFile: sums, signatureFile: []byte("not base64")}, "opensdk-1.0.0-linux-amd64.tar.gz", "", ErrBadSignature},
+		{"unsigned", map[string][]byte{checksumsFile: sums}, "opensdk-1.0.0-linux-amd64.tar.gz", "", nil},
+		{"no entry", map[string][]byte{checksumsFile: sums, signatureFile: sig}, "opensdk-1.0.0-macos-arm64.tar.gz", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			release, cleanup := serveRelease(tt.files)
+			defer cleanup()
+
+			got, err := release.checksum(context.Background(), http.DefaultClient, tt.name)
+
+			switch {
+			case tt.want != "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.want == "" && err == nil:
+				t.Fatalf("expected an error, got %q", got)
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("checksum = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecksumNoKey(t *testing.T) {
+	key := publicKey
+	publicKey = nil
+	t.Cleanup(func() { publicKey = key })
+
+	release, cleanup := serveRelease(map[string][]byte{
+		checksumsFile: []byte("0123abcd  opensdk-1.0.0-linux-amd64.tar.gz\n"),
+	})
+	defer cleanup()
+
+	got, err := release.checksum(context.Background(), http.DefaultClient, "opensdk-1.0.0-linux-amd64.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "0123abcd" {
+		t.Errorf("checksum = %q, want %q", got, "0123abcd")
+	}
+}
+
+func TestReplace(t *testing.T) {
+	dst := filepath.Join(t.TempDir(), binaryName())
+	if err := os.WriteFile(dst, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := replace(dst, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != "new" {
+		t.Errorf("%s = %q, want %q", dst, got, "new")
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(dst))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "."+Binary+"-") {
+			t.Errorf("temporary file %s left behind", entry.Name())
+		}
+	}
+}
+
+// serveRelease returns a release whose assets are files, served by a test
+// server that cleanup stops
+func serveRelease(files map[string][]byte) (Release, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write(data)
+	}))
+
+	var release Release
+	for name := range files {
+		release.Assets = append(release.Assets, Asset{Name: name, URL: srv.URL + "/" + name})
+	}
+
+	return release, srv.Close
+}
+
+// binaryName
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return Binary + ".exe"
+	}
+
+	return Binary
+}
+
+// tarGz
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, content := range files {
+		hdr := &tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// zipped
+func zipped(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}